@@ -1,6 +1,12 @@
 package api
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
 
 // BuildEventCreate represents the request payload for creating a build event
 type BuildEventCreate struct {
@@ -20,6 +26,9 @@ type BuildEventCreate struct {
 	StartedAt     *time.Time             `json:"started_at,omitempty"`
 	CompletedAt   *time.Time             `json:"completed_at,omitempty"`
 	ExtraMetadata map[string]interface{} `json:"extra_metadata,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header on every attempt
+	IdempotencyKey string `json:"-"`
 }
 
 // BuildResponse represents the response from creating a build event
@@ -30,19 +39,62 @@ type BuildResponse struct {
 	Status      string     `json:"status"`
 	StartedAt   *time.Time `json:"started_at,omitempty"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// Replayed is true when the API reported this as a replay of an earlier submission
+	Replayed bool `json:"-"`
+}
+
+func (e *BuildEventCreate) idempotencyKey() string {
+	return e.IdempotencyKey
 }
 
 // CreateBuildEvent sends a build event to the API
 func (c *Client) CreateBuildEvent(event *BuildEventCreate) (*BuildResponse, error) {
-	resp, err := c.doRequest("POST", "/build-events/", event)
+	return c.CreateBuildEventWithContext(context.Background(), event)
+}
+
+// CreateBuildEventWithContext sends a build event to the API, aborting
+// in-flight requests and retry backoff when ctx is cancelled
+func (c *Client) CreateBuildEventWithContext(ctx context.Context, event *BuildEventCreate) (*BuildResponse, error) {
+	resp, err := c.doRequest(ctx, "POST", "/build-events/", event)
 	if err != nil {
+		if c.canSpool(ctx) {
+			c.warnUnreachable(err)
+			if c.spoolEvent("build", event.IdempotencyKey, event.forSpool(), err) {
+				return &BuildResponse{Status: StatusQueued}, nil
+			}
+		}
 		return nil, err
 	}
 
 	var result BuildResponse
-	if err := c.handleResponse(resp, &result); err != nil {
+	if err := c.handleResponse(resp, &result, c.FailOnAPIError); err != nil {
 		return nil, err
 	}
+	result.Replayed = isReplayed(resp)
+
+	// API error tolerated by --fail-on-api-error=false
+	if result.Status == StatusNotRecorded && c.canSpool(ctx) {
+		if c.spoolEvent("build", event.IdempotencyKey, event.forSpool(), fmt.Errorf("HTTP %d", resp.StatusCode)) {
+			result.Status = StatusQueued
+		}
+	}
 
 	return &result, nil
 }
+
+// forSpool returns a copy of the event stamped with the time it happened, so a later
+// replay records the original timestamps
+func (e *BuildEventCreate) forSpool() *BuildEventCreate {
+	now := time.Now().UTC()
+	spooled := *e
+	canonical := status.GetCanonical(spooled.Status)
+	if spooled.StartedAt == nil && canonical == status.Started {
+		spooled.StartedAt = &now
+	}
+	if spooled.CompletedAt == nil && status.IsTerminal(canonical) {
+		spooled.CompletedAt = &now
+	}
+	spooled.ExtraMetadata = spoolMetadata(e.ExtraMetadata, now)
+	return &spooled
+}
@@ -2,16 +2,42 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/versioner-io/versioner-cli/internal/version"
 )
 
+const (
+	// idempotencyKeyHeader carries the idempotency key of an event submission
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader is set by the API when a request was answered from an earlier submission
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+const (
+	// StatusNotRecorded is the placeholder status returned when an event could not be recorded
+	StatusNotRecorded = "not_recorded"
+	// StatusQueued is the placeholder status returned when an event was queued for later delivery
+	StatusQueued = "queued"
+)
+
+// Spooler persists events that could not be delivered so they can be replayed later
+type Spooler interface {
+	Enqueue(kind, idempotencyKey string, payload interface{}, cause error) (string, error)
+}
+
+// idempotentRequest is implemented by request bodies that carry an idempotency key
+type idempotentRequest interface {
+	idempotencyKey() string
+}
+
 // Client represents the Versioner API client
 type Client struct {
 	BaseURL        string
@@ -19,7 +45,15 @@ type Client struct {
 	HTTPClient     *http.Client
 	UserAgent      string
 	Debug          bool
+	Verbose        bool
 	FailOnAPIError bool
+	Retry          RetryPolicy
+
+	// Spool receives events that could not be delivered when FailOnAPIError is false
+	Spool Spooler
+
+	// sleep waits between retry attempts (overridable in tests)
+	sleep func(ctx context.Context, d time.Duration) error
 }
 
 // NewClient creates a new API client
@@ -33,49 +67,103 @@ func NewClient(baseURL, apiKey string, debug bool, failOnAPIError bool) *Client
 		UserAgent:      version.GetUserAgent(),
 		Debug:          debug,
 		FailOnAPIError: failOnAPIError,
+		Retry:          DefaultRetryPolicy(),
 	}
 }
 
-// doRequest performs an HTTP request with retry logic
-func (c *Client) doRequest(method, path string, body interface{}) (*http.Response, error) {
+// doRequest performs an HTTP request, retrying network errors, 5xx and 429 responses
+// according to the client's retry policy. Cancelling ctx aborts both the in-flight
+// request and any backoff wait.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
 	var lastErr error
 
-	// Retry logic: 3 attempts with exponential backoff
-	backoffDurations := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second}
+	policy := c.Retry
+	if policy.MaxAttempts < 1 {
+		policy = DefaultRetryPolicy()
+	}
+	sleep := c.sleep
+	if sleep == nil {
+		sleep = SleepContext
+	}
 
-	for attempt := 0; attempt <= 3; attempt++ {
-		if attempt > 0 {
-			// Wait before retry
-			time.Sleep(backoffDurations[attempt-1])
-		}
+	start := time.Now()
+	attempt := 1
+	for ; ; attempt++ {
+		var retryAfter time.Duration
 
-		resp, err := c.performRequest(method, path, body)
+		resp, err := c.performRequest(ctx, method, path, body)
+		if ctx.Err() != nil {
+			// Cancelled or timed out - never retry
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, fmt.Errorf("request cancelled: %w", ctx.Err())
+		}
 		if err != nil {
-			lastErr = err
 			// Retry on network errors
-			continue
+			lastErr = err
+		} else {
+			// Success (2xx)
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return resp, nil
+			}
+
+			// Don't retry on 4xx errors (except 429 Too Many Requests)
+			if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != 429 {
+				return resp, nil
+			}
+
+			// Retry on 5xx errors and 429, honouring Retry-After when rate limited
+			lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+			if resp.StatusCode == 429 || resp.StatusCode == 503 {
+				if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+					retryAfter = wait
+				}
+			}
+			resp.Body.Close()
+		}
+
+		if attempt >= policy.MaxAttempts {
+			break
 		}
 
-		// Success (2xx)
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			return resp, nil
+		wait := policy.Backoff(attempt)
+		if retryAfter > wait {
+			wait = retryAfter
 		}
 
-		// Don't retry on 4xx errors (except 429 Too Many Requests)
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != 429 {
-			return resp, nil
+		if policy.Deadline > 0 && time.Since(start)+wait > policy.Deadline {
+			lastErr = fmt.Errorf("%w (retry deadline of %s exceeded)", lastErr, policy.Deadline)
+			break
 		}
 
-		// Retry on 5xx errors and 429
-		lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
-		resp.Body.Close()
+		if c.Verbose {
+			fmt.Fprintf(os.Stderr, "↻ Attempt %d/%d failed (%v), retrying in %s\n", attempt, policy.MaxAttempts, lastErr, wait.Round(time.Millisecond))
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, fmt.Errorf("request cancelled: %w", err)
+		}
 	}
 
-	return nil, fmt.Errorf("request failed after 3 retries: %w", lastErr)
+	return nil, fmt.Errorf("request failed after %d attempts: %w", attempt, lastErr)
+}
+
+// SleepContext waits for the given duration or until ctx is done, whichever comes first
+func SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // performRequest performs a single HTTP request
-func (c *Client) performRequest(method, path string, body interface{}) (*http.Response, error) {
+func (c *Client) performRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
 	url := c.BaseURL + path
 
 	var bodyReader io.Reader
@@ -87,11 +175,11 @@ func (c *Client) performRequest(method, path string, body interface{}) (*http.Re
 		bodyReader = bytes.NewReader(jsonBody)
 
 		if c.Debug {
-			fmt.Printf("→ Request body: %s\n", string(jsonBody))
+			fmt.Fprintf(os.Stderr, "→ Request body: %s\n", string(jsonBody))
 		}
 	}
 
-	req, err := http.NewRequest(method, url, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -100,10 +188,13 @@ func (c *Client) performRequest(method, path string, body interface{}) (*http.Re
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+c.APIKey)
 	req.Header.Set("User-Agent", c.UserAgent)
+	if idem, ok := body.(idempotentRequest); ok && idem.idempotencyKey() != "" {
+		req.Header.Set(idempotencyKeyHeader, idem.idempotencyKey())
+	}
 
 	if c.Debug {
-		fmt.Printf("→ %s %s\n", method, url)
-		fmt.Printf("→ Headers: %v\n", req.Header)
+		fmt.Fprintf(os.Stderr, "→ %s %s\n", method, url)
+		fmt.Fprintf(os.Stderr, "→ Headers: %v\n", req.Header)
 	}
 
 	resp, err := c.HTTPClient.Do(req)
@@ -112,14 +203,20 @@ func (c *Client) performRequest(method, path string, body interface{}) (*http.Re
 	}
 
 	if c.Debug {
-		fmt.Printf("← Status: %d\n", resp.StatusCode)
+		fmt.Fprintf(os.Stderr, "← Status: %d\n", resp.StatusCode)
 	}
 
 	return resp, nil
 }
 
-// handleResponse processes the API response
-func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
+// isReplayed reports whether the API answered a request from an earlier submission with the same idempotency key
+func isReplayed(resp *http.Response) bool {
+	return strings.EqualFold(resp.Header.Get(idempotentReplayedHeader), "true")
+}
+
+// handleResponse processes the API response. API errors are returned if failOnAPIError
+// is set, and otherwise tolerated as described by handleAPIError.
+func (c *Client) handleResponse(resp *http.Response, result interface{}, failOnAPIError bool) error {
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
@@ -147,6 +244,9 @@ func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
 			StatusCode: resp.StatusCode,
 			Detail:     string(body),
 		}
+		if failOnAPIError {
+			return apiError
+		}
 		return c.handleAPIError(apiError, result)
 	}
 
@@ -154,6 +254,9 @@ func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
 		StatusCode: resp.StatusCode,
 		Detail:     errorResponse.Detail,
 	}
+	if failOnAPIError {
+		return apiError
+	}
 
 	return c.handleAPIError(apiError, result)
 }
@@ -175,7 +278,11 @@ func (c *Client) handleAPIError(apiError *APIError, result interface{}) error {
 	fmt.Fprintf(os.Stderr, "⚠️  API Error (continuing due to --fail-on-api-error=false)\n")
 	fmt.Fprintf(os.Stderr, "    Status: %d\n", apiError.StatusCode)
 	fmt.Fprintf(os.Stderr, "    Error: %s\n", apiError.Error())
-	fmt.Fprintf(os.Stderr, "    ℹ️  Event was not recorded in Versioner\n\n")
+	if c.Spool != nil {
+		fmt.Fprintf(os.Stderr, "    ℹ️  Event was not recorded in Versioner yet\n")
+	} else {
+		fmt.Fprintf(os.Stderr, "    ℹ️  Event was not recorded in Versioner\n\n")
+	}
 
 	// Return placeholder response based on result type
 	if result != nil {
@@ -186,14 +293,14 @@ func (c *Client) handleAPIError(apiError *APIError, result interface{}) error {
 				ProductID:     "",
 				VersionID:     "",
 				EnvironmentID: "",
-				Status:        "not_recorded",
+				Status:        StatusNotRecorded,
 			}
 		case *BuildResponse:
 			*v = BuildResponse{
 				ID:        "",
 				ProductID: "",
 				VersionID: "",
-				Status:    "not_recorded",
+				Status:    StatusNotRecorded,
 			}
 		}
 	}
@@ -201,6 +308,40 @@ func (c *Client) handleAPIError(apiError *APIError, result interface{}) error {
 	return nil
 }
 
+// canSpool reports whether a failed event submission should be queued instead of failing
+func (c *Client) canSpool(ctx context.Context) bool {
+	return c.Spool != nil && !c.FailOnAPIError && ctx.Err() == nil
+}
+
+// warnUnreachable logs that the API could not be reached and the failure is being tolerated
+func (c *Client) warnUnreachable(err error) {
+	fmt.Fprintf(os.Stderr, "⚠️  API unreachable (continuing due to --fail-on-api-error=false)\n")
+	fmt.Fprintf(os.Stderr, "    Error: %s\n", err.Error())
+}
+
+// spoolEvent queues an undelivered event and reports whether it was queued
+func (c *Client) spoolEvent(kind, idempotencyKey string, event interface{}, cause error) bool {
+	path, err := c.Spool.Enqueue(kind, idempotencyKey, event, cause)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "    ⚠️  Could not queue event for later delivery: %s\n\n", err.Error())
+		return false
+	}
+
+	fmt.Fprintf(os.Stderr, "    📥 Event queued for later delivery: %s\n", path)
+	fmt.Fprintf(os.Stderr, "    Run 'versioner flush' to send queued events\n\n")
+	return true
+}
+
+// spoolMetadata returns a copy of extra metadata recording when an event was queued
+func spoolMetadata(metadata map[string]interface{}, queuedAt time.Time) map[string]interface{} {
+	merged := make(map[string]interface{}, len(metadata)+1)
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	merged["vi_queued_at"] = queuedAt.Format(time.RFC3339)
+	return merged
+}
+
 // APIError represents an error response from the API
 type APIError struct {
 	StatusCode int         `json:"-"`
@@ -227,6 +368,13 @@ func (e *APIError) IsPreflightError() bool {
 	return e.StatusCode == 409 || e.StatusCode == 423 || e.StatusCode == 428
 }
 
+// IsPermanent reports whether the API rejected the request itself (a malformed or invalid
+// event, or one referring to something that doesn't exist), so sending it again unchanged
+// cannot succeed. Authentication, preflight and rate limit errors may clear up later.
+func (e *APIError) IsPermanent() bool {
+	return e.StatusCode == 400 || e.StatusCode == 404 || e.StatusCode == 422
+}
+
 // GetPreflightDetails extracts structured preflight error details
 func (e *APIError) GetPreflightDetails() (errorType, message, code, retryAfter string, details map[string]interface{}, ok bool) {
 	detailMap, ok := e.Detail.(map[string]interface{})
@@ -1,9 +1,11 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestHandleAPIError_PreflightErrorsAlwaysFail(t *testing.T) {
@@ -229,3 +231,159 @@ func TestCreateBuildEvent_WithFailOnAPIError(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateDeploymentEvent_IdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			// Simulate a failure after the event was persisted
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "evt-1", "status": "started"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", false, true)
+	client.sleep = func(context.Context, time.Duration) error { return nil }
+
+	event := &DeploymentEventCreate{
+		ProductName:     "test-product",
+		Version:         "1.0.0",
+		EnvironmentName: "production",
+		Status:          "started",
+		IdempotencyKey:  "key-123",
+	}
+
+	resp, err := client.CreateDeploymentEvent(event)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(keys) != 2 || keys[0] != "key-123" || keys[1] != "key-123" {
+		t.Errorf("Expected the same idempotency key on every attempt, got %v", keys)
+	}
+	if !resp.Replayed {
+		t.Errorf("Expected response to be marked as replayed")
+	}
+}
+
+type fakeSpooler struct {
+	kinds []string
+	keys  []string
+}
+
+func (f *fakeSpooler) Enqueue(kind, idempotencyKey string, payload interface{}, cause error) (string, error) {
+	f.kinds = append(f.kinds, kind)
+	f.keys = append(f.keys, idempotencyKey)
+	return "/tmp/spooled.json", nil
+}
+
+func TestCreateDeploymentEvent_SpoolsWhenAPIErrorsTolerated(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		failOnAPIError bool
+		expectSpooled  bool
+		expectError    bool
+	}{
+		{"401 with fail=false", 401, false, true, false},
+		{"503 with fail=false", 503, false, true, false},
+		{"401 with fail=true", 401, true, false, true},
+		{"409 with fail=false (preflight)", 409, false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(`{"detail": "error message"}`))
+			}))
+			defer server.Close()
+
+			spooler := &fakeSpooler{}
+			client := NewClient(server.URL, "test-key", false, tt.failOnAPIError)
+			client.Retry = RetryPolicy{MaxAttempts: 1}
+			client.Spool = spooler
+
+			event := &DeploymentEventCreate{
+				ProductName:     "test-product",
+				Version:         "1.0.0",
+				EnvironmentName: "production",
+				Status:          "completed",
+				IdempotencyKey:  "key-123",
+			}
+
+			resp, err := client.CreateDeploymentEvent(event)
+
+			if tt.expectError != (err != nil) {
+				t.Errorf("Expected error=%v, got: %v", tt.expectError, err)
+			}
+			if spooled := len(spooler.kinds) == 1; spooled != tt.expectSpooled {
+				t.Fatalf("Expected spooled=%v, got %d spooled events", tt.expectSpooled, len(spooler.kinds))
+			}
+			if tt.expectSpooled {
+				if resp.Status != StatusQueued {
+					t.Errorf("Expected status=%s, got %s", StatusQueued, resp.Status)
+				}
+				if spooler.kinds[0] != "deployment" || spooler.keys[0] != "key-123" {
+					t.Errorf("Expected deployment event with key-123, got %s/%s", spooler.kinds[0], spooler.keys[0])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckPreflight(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		body         string
+		expectError  bool
+		wantWarnings int
+	}{
+		{"allowed", 200, `{}`, false, 0},
+		{"allowed with report_only warnings", 200, `{"warnings": [{"rule_name": "No Deploy Fridays", "code": "NO_DEPLOY_WINDOW", "message": "blocked on Fridays"}]}`, false, 1},
+		{"blocked by schedule", 423, `{"detail": {"code": "NO_DEPLOY_WINDOW", "message": "blocked"}}`, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key", false, true)
+			event := &DeploymentEventCreate{ProductName: "p", Version: "1", EnvironmentName: "production", Status: "started"}
+
+			resp, err := client.CheckPreflight(context.Background(), event)
+
+			if path != "/deployment-events/preflight" {
+				t.Errorf("Expected request to /deployment-events/preflight, got %s", path)
+			}
+			if tt.expectError {
+				apiErr, ok := err.(*APIError)
+				if !ok || !apiErr.IsPreflightError() {
+					t.Fatalf("Expected preflight APIError, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(resp.Warnings) != tt.wantWarnings {
+				t.Errorf("Expected %d warnings, got %d", tt.wantWarnings, len(resp.Warnings))
+			}
+		})
+	}
+}
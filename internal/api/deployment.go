@@ -1,6 +1,12 @@
 package api
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
 
 // DeploymentEventCreate represents the request payload for creating a deployment event
 type DeploymentEventCreate struct {
@@ -20,6 +26,9 @@ type DeploymentEventCreate struct {
 	CompletedAt         *time.Time             `json:"completed_at,omitempty"`
 	SkipPreflightChecks bool                   `json:"skip_preflight_checks,omitempty"`
 	ExtraMetadata       map[string]interface{} `json:"extra_metadata,omitempty"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header on every attempt
+	IdempotencyKey string `json:"-"`
 }
 
 // DeploymentResponse represents the response from creating a deployment event
@@ -30,6 +39,12 @@ type DeploymentResponse struct {
 	EnvironmentID string     `json:"environment_id"`
 	Status        string     `json:"status"`
 	DeployedAt    *time.Time `json:"deployed_at,omitempty"`
+
+	// Warnings lists report_only rules that would have blocked the deployment
+	Warnings []PreflightWarning `json:"warnings,omitempty"`
+
+	// Replayed is true when the API reported this as a replay of an earlier submission
+	Replayed bool `json:"-"`
 }
 
 // PreflightError represents a preflight check failure with detailed information
@@ -42,15 +57,82 @@ type PreflightError struct {
 	RetryAfter string                 `json:"retry_after,omitempty"`
 }
 
+func (e *DeploymentEventCreate) idempotencyKey() string {
+	return e.IdempotencyKey
+}
+
+// PreflightWarning represents a report_only rule that failed without blocking the deployment
+type PreflightWarning struct {
+	RuleName   string                 `json:"rule_name,omitempty"`
+	Code       string                 `json:"code,omitempty"`
+	Message    string                 `json:"message"`
+	RetryAfter string                 `json:"retry_after,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+}
+
+// PreflightResponse represents a deployment that passed preflight checks without being recorded
+type PreflightResponse struct {
+	Warnings []PreflightWarning `json:"warnings,omitempty"`
+}
+
 // CreateDeploymentEvent sends a deployment event to the API
 func (c *Client) CreateDeploymentEvent(event *DeploymentEventCreate) (*DeploymentResponse, error) {
-	resp, err := c.doRequest("POST", "/deployment-events/", event)
+	return c.CreateDeploymentEventWithContext(context.Background(), event)
+}
+
+// CreateDeploymentEventWithContext sends a deployment event to the API, aborting
+// in-flight requests and retry backoff when ctx is cancelled
+func (c *Client) CreateDeploymentEventWithContext(ctx context.Context, event *DeploymentEventCreate) (*DeploymentResponse, error) {
+	resp, err := c.doRequest(ctx, "POST", "/deployment-events/", event)
 	if err != nil {
+		if c.canSpool(ctx) {
+			c.warnUnreachable(err)
+			if c.spoolEvent("deployment", event.IdempotencyKey, event.forSpool(), err) {
+				return &DeploymentResponse{Status: StatusQueued}, nil
+			}
+		}
 		return nil, err
 	}
 
 	var result DeploymentResponse
-	if err := c.handleResponse(resp, &result); err != nil {
+	if err := c.handleResponse(resp, &result, c.FailOnAPIError); err != nil {
+		return nil, err
+	}
+	result.Replayed = isReplayed(resp)
+
+	// API error tolerated by --fail-on-api-error=false
+	if result.Status == StatusNotRecorded && c.canSpool(ctx) {
+		if c.spoolEvent("deployment", event.IdempotencyKey, event.forSpool(), fmt.Errorf("HTTP %d", resp.StatusCode)) {
+			result.Status = StatusQueued
+		}
+	}
+
+	return &result, nil
+}
+
+// forSpool returns a copy of the event stamped with the time it happened, so a later
+// replay records the original timestamps
+func (e *DeploymentEventCreate) forSpool() *DeploymentEventCreate {
+	now := time.Now().UTC()
+	spooled := *e
+	if spooled.CompletedAt == nil && status.IsTerminal(spooled.Status) {
+		spooled.CompletedAt = &now
+	}
+	spooled.ExtraMetadata = spoolMetadata(e.ExtraMetadata, now)
+	return &spooled
+}
+
+// CheckPreflight asks the API to evaluate preflight rules for a deployment without
+// recording it. A blocked deployment is returned as an *APIError (409, 423 or 428),
+// exactly as for a 'started' deployment event.
+func (c *Client) CheckPreflight(ctx context.Context, event *DeploymentEventCreate) (*PreflightResponse, error) {
+	resp, err := c.doRequest(ctx, "POST", "/deployment-events/preflight", event)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PreflightResponse
+	if err := c.handleResponse(resp, &result, c.FailOnAPIError); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// listPageSize is the page size requested when following paginated list endpoints
+const listPageSize = 100
+
+// Deployment represents a recorded deployment
+type Deployment struct {
+	ID              string     `json:"id"`
+	ProductName     string     `json:"product_name"`
+	Version         string     `json:"version"`
+	EnvironmentName string     `json:"environment_name"`
+	Status          string     `json:"status"`
+	BuildNumber     string     `json:"build_number,omitempty"`
+	SCMSha          string     `json:"scm_sha,omitempty"`
+	SCMRepository   string     `json:"scm_repository,omitempty"`
+	DeployURL       string     `json:"deploy_url,omitempty"`
+	DeployedBy      string     `json:"deployed_by,omitempty"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	DeployedAt      *time.Time `json:"deployed_at,omitempty"`
+
+	ExtraMetadata map[string]interface{} `json:"extra_metadata,omitempty"`
+}
+
+// DeploymentFilter narrows down the deployments returned by ListDeployments
+type DeploymentFilter struct {
+	ProductName     string
+	EnvironmentName string
+	Status          string
+	Since           time.Time
+	Until           time.Time
+
+	// Limit caps the number of deployments returned (0 = no limit)
+	Limit int
+}
+
+// Version represents a version of a product
+type Version struct {
+	ID            string     `json:"id"`
+	ProductName   string     `json:"product_name"`
+	Version       string     `json:"version"`
+	BuildNumber   string     `json:"build_number,omitempty"`
+	SCMSha        string     `json:"scm_sha,omitempty"`
+	SCMRepository string     `json:"scm_repository,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+}
+
+// EnvironmentVersion represents the version of a product currently deployed to an environment
+type EnvironmentVersion struct {
+	EnvironmentName string     `json:"environment_name"`
+	ProductName     string     `json:"product_name"`
+	Version         string     `json:"version"`
+	Status          string     `json:"status,omitempty"`
+	DeployedBy      string     `json:"deployed_by,omitempty"`
+	DeployedAt      *time.Time `json:"deployed_at,omitempty"`
+}
+
+// listPage is one page of a paginated list response
+type listPage[T any] struct {
+	Items    []T `json:"items"`
+	Total    int `json:"total"`
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
+
+// ListDeployments returns recorded deployments matching filter, most recent first
+func (c *Client) ListDeployments(ctx context.Context, filter DeploymentFilter) ([]Deployment, error) {
+	query := url.Values{}
+	setQuery(query, "product_name", filter.ProductName)
+	setQuery(query, "environment_name", filter.EnvironmentName)
+	setQuery(query, "status", filter.Status)
+	if !filter.Since.IsZero() {
+		query.Set("since", filter.Since.UTC().Format(time.RFC3339))
+	}
+	if !filter.Until.IsZero() {
+		query.Set("until", filter.Until.UTC().Format(time.RFC3339))
+	}
+
+	return listAll[Deployment](ctx, c, "/deployment-events/", query, filter.Limit)
+}
+
+// ListVersions returns the versions of a product, most recent first
+func (c *Client) ListVersions(ctx context.Context, productName string, limit int) ([]Version, error) {
+	query := url.Values{}
+	setQuery(query, "product_name", productName)
+
+	return listAll[Version](ctx, c, "/versions/", query, limit)
+}
+
+// ListEnvironments returns the version of each product currently deployed to each environment
+func (c *Client) ListEnvironments(ctx context.Context, productName string) ([]EnvironmentVersion, error) {
+	query := url.Values{}
+	setQuery(query, "product_name", productName)
+
+	return listAll[EnvironmentVersion](ctx, c, "/environments/", query, 0)
+}
+
+// listAll follows a paginated list endpoint until every page has been read or
+// limit items (0 = no limit) were collected. If the API leaves out the total, pages
+// are read until a short or empty one.
+func listAll[T any](ctx context.Context, c *Client, path string, query url.Values, limit int) ([]T, error) {
+	pageSize := listPageSize
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+
+	var items []T
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		query.Set("page_size", strconv.Itoa(pageSize))
+
+		resp, err := c.doRequest(ctx, "GET", path+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result listPage[T]
+		// A query has no placeholder result, so API errors always fail it, whatever
+		// FailOnAPIError says about the events of the command
+		if err := c.handleResponse(resp, &result, true); err != nil {
+			return nil, err
+		}
+		items = append(items, result.Items...)
+
+		if len(result.Items) == 0 {
+			break
+		}
+		if result.Total > 0 && len(items) >= result.Total {
+			break
+		}
+		// Without a total, a short page is the last one
+		if result.Total == 0 && len(result.Items) < servedPageSize(result.PageSize, pageSize) {
+			break
+		}
+		if limit > 0 && len(items) >= limit {
+			break
+		}
+	}
+
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// servedPageSize returns the page size the API reports using, which may be capped below
+// the requested one, or the requested size if it doesn't say
+func servedPageSize(reported, requested int) int {
+	if reported > 0 {
+		return reported
+	}
+	return requested
+}
+
+// setQuery sets a query parameter if value is not empty
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// pagedServer serves total deployments in pages of the requested size, leaving out the
+// total from responses if omitTotal is set
+func pagedServer(t *testing.T, total int, omitTotal bool, queries *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+		items := []Deployment{}
+		for i := (page - 1) * pageSize; i < page*pageSize && i < total; i++ {
+			items = append(items, Deployment{ID: fmt.Sprintf("d%d", i), Version: "1.0." + strconv.Itoa(i)})
+		}
+
+		body := map[string]interface{}{"items": items, "total": total, "page": page, "page_size": pageSize}
+		if omitTotal {
+			delete(body, "total")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+}
+
+func TestListDeployments_Pagination(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		omitTotal bool
+		limit     int
+		wantItems int
+		wantPages int
+	}{
+		{"empty", 0, false, 0, 0, 1},
+		{"single page", 3, false, 0, 3, 1},
+		{"follows all pages", 250, false, 0, 250, 3},
+		{"stops at limit", 250, false, 120, 120, 2},
+		{"limit within first page", 250, false, 10, 10, 1},
+		{"without total, stops at a short page", 250, true, 0, 250, 3},
+		{"without total, stops at an empty page", 200, true, 0, 200, 3},
+		{"without total, single page", 3, true, 0, 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			server := pagedServer(t, tt.total, tt.omitTotal, &queries)
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key", false, true)
+			deployments, err := client.ListDeployments(context.Background(), DeploymentFilter{Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if len(deployments) != tt.wantItems {
+				t.Errorf("Expected %d deployments, got %d", tt.wantItems, len(deployments))
+			}
+			if len(queries) != tt.wantPages {
+				t.Errorf("Expected %d page requests, got %d", tt.wantPages, len(queries))
+			}
+		})
+	}
+}
+
+func TestListDeployments_Filters(t *testing.T) {
+	var query map[string][]string
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items": [], "total": 0, "page": 1, "page_size": 100}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", false, true)
+	filter := DeploymentFilter{
+		ProductName:     "api-service",
+		EnvironmentName: "production",
+		Status:          "failed",
+		Since:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if _, err := client.ListDeployments(context.Background(), filter); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if path != "/deployment-events/" {
+		t.Errorf("Expected request to /deployment-events/, got %s", path)
+	}
+
+	expected := map[string]string{
+		"product_name":     "api-service",
+		"environment_name": "production",
+		"status":           "failed",
+		"since":            "2025-01-01T00:00:00Z",
+	}
+	for key, want := range expected {
+		if got := query[key]; len(got) != 1 || got[0] != want {
+			t.Errorf("Expected %s=%s, got %v", key, want, got)
+		}
+	}
+	if _, ok := query["until"]; ok {
+		t.Error("Expected no until parameter")
+	}
+}
+
+func TestListEnvironments_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(401)
+		_, _ = w.Write([]byte(`{"detail": "Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	// Queries fail on API errors even when event commands tolerate them
+	for _, failOnAPIError := range []bool{true, false} {
+		client := NewClient(server.URL, "test-key", false, failOnAPIError)
+		_, err := client.ListEnvironments(context.Background(), "api-service")
+
+		apiErr, ok := err.(*APIError)
+		if !ok || apiErr.StatusCode != 401 {
+			t.Errorf("Expected 401 APIError (fail on API error: %v), got: %v", failOnAPIError, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// BaseBackoff is the wait before the first retry; it doubles on each subsequent retry
+	BaseBackoff time.Duration
+	// MaxBackoff caps the exponential backoff between attempts
+	MaxBackoff time.Duration
+	// Jitter randomizes each backoff by up to this fraction (0.0 - 1.0) in either direction
+	Jitter float64
+	// Deadline is the total time budget for all attempts (0 means no limit)
+	Deadline time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured:
+// 4 attempts with 1s/2s/4s backoff, 10% jitter and a 2 minute deadline
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: 1 * time.Second,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.1,
+		Deadline:    2 * time.Minute,
+	}
+}
+
+// Validate checks that the policy values are usable
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 1 {
+		return fmt.Errorf("retry max attempts must be at least 1 (got %d)", p.MaxAttempts)
+	}
+	if p.BaseBackoff < 0 || p.MaxBackoff < 0 || p.Deadline < 0 {
+		return fmt.Errorf("retry durations must not be negative")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("retry jitter must be between 0 and 1 (got %g)", p.Jitter)
+	}
+	return nil
+}
+
+// Backoff returns the wait before the given retry (1 = first retry)
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	if retry < 1 || p.BaseBackoff <= 0 {
+		return 0
+	}
+
+	backoff := p.BaseBackoff
+	for i := 1; i < retry; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		delta := float64(backoff) * p.Jitter
+		backoff = time.Duration(float64(backoff) - delta + rand.Float64()*2*delta)
+	}
+
+	return backoff
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a number of
+// seconds or an HTTP-date, into the duration to wait from now
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		wait := at.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 11, 21, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"seconds", "120", 120 * time.Second, true},
+		{"zero seconds", "0", 0, true},
+		{"seconds with whitespace", " 5 ", 5 * time.Second, true},
+		{"HTTP date", "Fri, 21 Nov 2025 10:00:30 GMT", 30 * time.Second, true},
+		{"HTTP date in the past", "Fri, 21 Nov 2025 09:00:00 GMT", 0, true},
+		{"empty", "", 0, false},
+		{"negative seconds", "-1", 0, false},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 6, BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, expected := range want {
+		if got := policy.Backoff(i + 1); got != expected {
+			t.Errorf("Backoff(%d) = %v, want %v", i+1, got, expected)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		got := policy.Backoff(1)
+		if got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("Backoff(1) with 50%% jitter = %v, want between 500ms and 1.5s", got)
+		}
+	}
+}
+
+func TestRetryPolicyValidate(t *testing.T) {
+	if err := DefaultRetryPolicy().Validate(); err != nil {
+		t.Errorf("Expected default policy to be valid, got: %v", err)
+	}
+
+	invalid := []RetryPolicy{
+		{MaxAttempts: 0},
+		{MaxAttempts: 1, Jitter: 1.5},
+		{MaxAttempts: 1, BaseBackoff: -time.Second},
+	}
+	for _, policy := range invalid {
+		if err := policy.Validate(); err == nil {
+			t.Errorf("Expected error for policy %+v, got nil", policy)
+		}
+	}
+}
+
+func TestDoRequest_HonoursRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "evt-1"}`))
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	client := NewClient(server.URL, "test-key", false, true)
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	resp, err := client.CreateBuildEvent(&BuildEventCreate{ProductName: "p", Version: "1", Status: "completed"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.ID != "evt-1" {
+		t.Errorf("Expected event ID evt-1, got %s", resp.ID)
+	}
+	if len(waits) != 1 || waits[0] != 7*time.Second {
+		t.Errorf("Expected a single 7s wait from Retry-After, got %v", waits)
+	}
+}
+
+func TestDoRequest_MaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", false, true)
+	client.Retry = RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}
+	client.sleep = func(context.Context, time.Duration) error { return nil }
+
+	_, err := client.CreateBuildEvent(&BuildEventCreate{ProductName: "p", Version: "1", Status: "completed"})
+	if err == nil {
+		t.Fatal("Expected error after exhausting retries, got nil")
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestDoRequest_DeadlineStopsRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", false, true)
+	client.Retry = RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Millisecond, Deadline: time.Minute}
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		t.Errorf("Expected no sleep beyond the deadline, got %v", d)
+		return nil
+	}
+
+	_, err := client.CreateBuildEvent(&BuildEventCreate{ProductName: "p", Version: "1", Status: "completed"})
+	if err == nil {
+		t.Fatal("Expected error when Retry-After exceeds the deadline, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestDoRequest_CancelAbortsBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", false, true)
+	client.Retry = RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Millisecond}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.CreateBuildEventWithContext(ctx, &BuildEventCreate{ProductName: "p", Version: "1", Status: "completed"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected cancellation to abort the backoff wait, took %v", elapsed)
+	}
+}
+
+func TestDoRequest_CancelAbortsInFlightRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, "test-key", false, true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := client.CreateDeploymentEventWithContext(ctx, &DeploymentEventCreate{ProductName: "p", Version: "1", EnvironmentName: "prod", Status: "started"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+}
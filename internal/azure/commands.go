@@ -0,0 +1,148 @@
+package azure
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/report"
+)
+
+func init() {
+	report.Register(cicd.SystemAzure, func() report.Reporter { return NewReporter() })
+}
+
+// Reporter publishes command outcomes as Azure Pipelines logging commands
+type Reporter struct {
+	report.Base
+
+	// Stderr receives logging commands (the agent processes them on both streams,
+	// and stdout is reserved for --output documents)
+	Stderr io.Writer
+	// TempDir is where summary files are written before they are uploaded
+	TempDir string
+}
+
+// NewReporter returns a reporter for the current Azure Pipelines job
+func NewReporter() *Reporter {
+	dir := os.Getenv("AGENT_TEMPDIRECTORY")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return &Reporter{Stderr: os.Stderr, TempDir: dir}
+}
+
+// Success attaches a markdown summary to the pipeline run and, for deployments, tags
+// the run with the deployed version
+func (r *Reporter) Success(s report.Success) {
+	r.uploadSummary("versioner", report.SuccessSummary(s))
+	if s.Action == "Deployment" && s.Version != "" {
+		r.writeCommand("build.addbuildtag", nil, s.Version)
+	}
+}
+
+// APIError logs an error issue, shown in the pipeline run summary
+func (r *Reporter) APIError(f report.Failure) {
+	r.writeIssue("error", "Versioner "+f.Action+" Failed", f.Message)
+}
+
+// NetworkError logs an error issue, shown in the pipeline run summary
+func (r *Reporter) NetworkError(f report.Failure) {
+	r.writeIssue("error", "Versioner "+f.Action+" Failed", f.Message)
+}
+
+// PreflightRejection logs a preflight rejection as an error issue
+func (r *Reporter) PreflightRejection(rej report.Rejection) {
+	title := "Versioner Deployment Rejected"
+	if rej.Code != "" {
+		title += " (" + rej.Code + ")"
+	}
+	message := rej.Message
+	if rej.RuleName != "" {
+		message += " [rule: " + rej.RuleName + "]"
+	}
+	if rej.RetryAfter != "" {
+		message += " Retry after " + rej.RetryAfter + "."
+	}
+	r.writeIssue("error", title, message)
+}
+
+// Warning logs a warning issue, such as a report_only deployment rule that failed
+func (r *Reporter) Warning(title, message string) {
+	r.writeIssue("warning", title, message)
+}
+
+// Result sets pipeline variables for later steps, as $(NAME) in the same job and as
+// output variables ($(step.NAME) / dependencies) for later jobs. Empty values are left out.
+func (r *Reporter) Result(res report.Result) {
+	for _, v := range res.Variables {
+		if v.Value == "" {
+			continue
+		}
+		r.writeCommand("task.setvariable", [][2]string{{"variable", v.Name}}, v.Value)
+		r.writeCommand("task.setvariable", [][2]string{{"variable", v.Name}, {"isOutput", "true"}}, v.Value)
+	}
+}
+
+// writeIssue logs an issue of the given type ("error" or "warning")
+func (r *Reporter) writeIssue(issueType, title, message string) {
+	text := message
+	if title != "" {
+		text = title + ": " + message
+	}
+	r.writeCommand("task.logissue", [][2]string{{"type", issueType}}, text)
+}
+
+// uploadSummary attaches a markdown summary to the pipeline run
+func (r *Reporter) uploadSummary(name, markdown string) {
+	f, err := os.CreateTemp(r.TempDir, name+"-*.md")
+	if err != nil {
+		// Silently fail - don't break the CLI if we can't write the summary
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(markdown); err != nil {
+		return
+	}
+
+	path, err := filepath.Abs(f.Name())
+	if err != nil {
+		path = f.Name()
+	}
+	r.writeCommand("task.uploadsummary", nil, path)
+}
+
+// writeCommand writes a ##vso logging command
+func (r *Reporter) writeCommand(command string, properties [][2]string, data string) {
+	fmt.Fprintln(r.Stderr, formatCommand(command, properties, data))
+}
+
+// formatCommand renders a ##vso logging command, escaping properties and data
+func formatCommand(command string, properties [][2]string, data string) string {
+	var b strings.Builder
+
+	b.WriteString("##vso[" + command)
+	for i, p := range properties {
+		if i == 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(p[0] + "=" + escapeProperty(p[1]) + ";")
+	}
+	b.WriteString("]" + escapeData(data))
+
+	return b.String()
+}
+
+// escapeData escapes the data of a logging command, which must stay on one line
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a logging command property value
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", "]", "%5D", ";", "%3B").Replace(s)
+}
@@ -0,0 +1,103 @@
+package azure
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/report"
+)
+
+func TestFormatCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		command    string
+		properties [][2]string
+		data       string
+		want       string
+	}{
+		{"error issue", "task.logissue", [][2]string{{"type", "error"}}, "Deployment blocked", "##vso[task.logissue type=error;]Deployment blocked"},
+		{"variable", "task.setvariable", [][2]string{{"variable", "VERSIONER_EVENT_ID"}, {"isOutput", "true"}}, "evt-1", "##vso[task.setvariable variable=VERSIONER_EVENT_ID;isOutput=true;]evt-1"},
+		{"no properties", "build.addbuildtag", nil, "1.2.3", "##vso[build.addbuildtag]1.2.3"},
+		{"multiline data", "task.logissue", [][2]string{{"type", "error"}}, "line one\nline two 100%", "##vso[task.logissue type=error;]line one%0Aline two 100%AZP25"},
+		{"property escaping", "task.setvariable", [][2]string{{"variable", "a;b]c"}}, "x", "##vso[task.setvariable variable=a%3Bb%5Dc;]x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCommand(tt.command, tt.properties, tt.data); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func newTestReporter(t *testing.T) (*Reporter, *bytes.Buffer) {
+	var stderr bytes.Buffer
+	return &Reporter{Stderr: &stderr, TempDir: t.TempDir()}, &stderr
+}
+
+func TestReporter_Success(t *testing.T) {
+	r, stderr := newTestReporter(t)
+
+	r.Success(report.Success{Action: "Deployment", Environment: "production", Status: "completed", Version: "1.2.3"})
+
+	entries, err := os.ReadDir(r.TempDir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected one summary file, got %v (%v)", entries, err)
+	}
+	path := filepath.Join(r.TempDir, entries[0].Name())
+	if !strings.HasPrefix(entries[0].Name(), "versioner-") || !strings.HasSuffix(path, ".md") {
+		t.Errorf("Expected a versioner-*.md file, got %s", entries[0].Name())
+	}
+	if content, _ := os.ReadFile(path); !strings.Contains(string(content), "Versioner Summary") {
+		t.Errorf("Expected the success summary, got %q", content)
+	}
+
+	want := "##vso[task.uploadsummary]" + path + "\n##vso[build.addbuildtag]1.2.3\n"
+	if stderr.String() != want {
+		t.Errorf("Expected %q, got %q", want, stderr.String())
+	}
+}
+
+func TestReporter_BuildSuccessNotTagged(t *testing.T) {
+	r, stderr := newTestReporter(t)
+
+	r.Success(report.Success{Action: "Build", Status: "completed", Version: "1.2.3"})
+
+	if strings.Contains(stderr.String(), "build.addbuildtag") {
+		t.Errorf("Expected no build tag for builds, got %q", stderr.String())
+	}
+}
+
+func TestReporter_PreflightRejection(t *testing.T) {
+	r, stderr := newTestReporter(t)
+
+	r.PreflightRejection(report.Rejection{StatusCode: 423, Code: "NO_DEPLOY_WINDOW", Message: "No deploys on Fridays", RuleName: "Friday Freeze", RetryAfter: "2025-01-06T09:00:00Z"})
+
+	want := "##vso[task.logissue type=error;]Versioner Deployment Rejected (NO_DEPLOY_WINDOW): No deploys on Fridays [rule: Friday Freeze] Retry after 2025-01-06T09:00:00Z.\n"
+	if stderr.String() != want {
+		t.Errorf("Expected %q, got %q", want, stderr.String())
+	}
+}
+
+func TestReporter_Result(t *testing.T) {
+	r, stderr := newTestReporter(t)
+
+	r.Result(report.Result{Variables: []report.Variable{{Name: "VERSIONER_EVENT_ID", Value: "evt-1"}, {Name: "VERSIONER_ERROR", Value: ""}}})
+
+	want := "##vso[task.setvariable variable=VERSIONER_EVENT_ID;]evt-1\n" +
+		"##vso[task.setvariable variable=VERSIONER_EVENT_ID;isOutput=true;]evt-1\n"
+	if stderr.String() != want {
+		t.Errorf("Expected %q, got %q", want, stderr.String())
+	}
+}
+
+func TestReporterRegistered(t *testing.T) {
+	if len(report.ForSystem(cicd.SystemAzure)) == 0 {
+		t.Error("Expected a reporter registered for Azure Pipelines")
+	}
+}
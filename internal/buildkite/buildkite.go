@@ -0,0 +1,95 @@
+package buildkite
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/report"
+)
+
+func init() {
+	report.Register(cicd.SystemBuildkite, func() report.Reporter { return NewReporter() })
+}
+
+// Agent runs a buildkite-agent subcommand with stdin as its standard input
+type Agent func(stdin string, args ...string) error
+
+// Reporter publishes command outcomes as Buildkite annotations and build meta-data
+type Reporter struct {
+	report.Base
+
+	// Agent runs buildkite-agent; tests replace it to capture invocations
+	Agent Agent
+}
+
+// NewReporter returns a reporter for the current Buildkite job
+func NewReporter() *Reporter {
+	return &Reporter{Agent: runAgent}
+}
+
+// Success annotates the build with a summary of the tracked event
+func (r *Reporter) Success(s report.Success) {
+	r.annotate("success", annotationContext(s.Action), report.SuccessSummary(s))
+}
+
+// APIError annotates the build with a request the API rejected
+func (r *Reporter) APIError(f report.Failure) {
+	r.annotate("error", annotationContext(f.Action), report.FailureSummary(f, "API Error"))
+}
+
+// NetworkError annotates the build with a request that could not reach the API
+func (r *Reporter) NetworkError(f report.Failure) {
+	r.annotate("error", annotationContext(f.Action), report.FailureSummary(f, "Network Error"))
+}
+
+// PreflightRejection annotates the build with a deployment blocked by preflight checks
+func (r *Reporter) PreflightRejection(rej report.Rejection) {
+	r.annotate("error", annotationContext("Deployment"), report.RejectionSummary(rej))
+}
+
+// Warning appends a non-blocking issue to the build's warnings annotation
+func (r *Reporter) Warning(title, message string) {
+	r.run("- **"+title+"**: "+message+"\n", "annotate", "--style", "warning", "--context", "versioner-warnings", "--append")
+}
+
+// Result sets build meta-data for later steps, readable with
+// `buildkite-agent meta-data get VERSIONER_EVENT_ID`. Empty values are left out.
+func (r *Reporter) Result(res report.Result) {
+	for _, v := range res.Variables {
+		if v.Value == "" {
+			continue
+		}
+		r.run(v.Value, "meta-data", "set", v.Name)
+	}
+}
+
+// annotate replaces the annotation for context with markdown
+func (r *Reporter) annotate(style, context, markdown string) {
+	r.run(markdown, "annotate", "--style", style, "--context", context)
+}
+
+// run invokes the agent, ignoring failures
+func (r *Reporter) run(stdin string, args ...string) {
+	if r.Agent == nil {
+		return
+	}
+	// Silently fail - don't break the CLI if the agent is unavailable
+	_ = r.Agent(stdin, args...)
+}
+
+// annotationContext returns the annotation context for an action, so a later event for
+// the same action (e.g. completed after started) replaces the earlier annotation
+func annotationContext(action string) string {
+	return "versioner-" + strings.ToLower(action)
+}
+
+// runAgent runs buildkite-agent from PATH
+func runAgent(stdin string, args ...string) error {
+	cmd := exec.Command("buildkite-agent", args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = os.Stderr // stdout is reserved for --output documents
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,82 @@
+package buildkite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/report"
+)
+
+// invocation is a captured buildkite-agent call
+type invocation struct {
+	stdin string
+	args  string
+}
+
+func newTestReporter() (*Reporter, *[]invocation) {
+	var calls []invocation
+	return &Reporter{Agent: func(stdin string, args ...string) error {
+		calls = append(calls, invocation{stdin, strings.Join(args, " ")})
+		return nil
+	}}, &calls
+}
+
+func TestReporter_Success(t *testing.T) {
+	r, calls := newTestReporter()
+
+	r.Success(report.Success{Action: "Deployment", Environment: "production", Status: "completed", Version: "1.2.3"})
+
+	if len(*calls) != 1 {
+		t.Fatalf("Expected one agent call, got %v", *calls)
+	}
+	if want := "annotate --style success --context versioner-deployment"; (*calls)[0].args != want {
+		t.Errorf("Expected %q, got %q", want, (*calls)[0].args)
+	}
+	if !strings.Contains((*calls)[0].stdin, "production") {
+		t.Errorf("Expected the annotation to mention the environment, got %q", (*calls)[0].stdin)
+	}
+}
+
+func TestReporter_PreflightRejection(t *testing.T) {
+	r, calls := newTestReporter()
+
+	r.PreflightRejection(report.Rejection{StatusCode: 423, Code: "NO_DEPLOY_WINDOW", Message: "No deploys on Fridays", RuleName: "Friday Freeze"})
+
+	if len(*calls) != 1 || (*calls)[0].args != "annotate --style error --context versioner-deployment" {
+		t.Fatalf("Expected an error annotation, got %v", *calls)
+	}
+	for _, want := range []string{"NO_DEPLOY_WINDOW", "Friday Freeze", "No deploys on Fridays"} {
+		if !strings.Contains((*calls)[0].stdin, want) {
+			t.Errorf("Expected the annotation to contain %q", want)
+		}
+	}
+}
+
+func TestReporter_Warning(t *testing.T) {
+	r, calls := newTestReporter()
+
+	r.Warning("Deployment Rule Warning: Soak Time", "Soak time not met")
+
+	want := invocation{"- **Deployment Rule Warning: Soak Time**: Soak time not met\n", "annotate --style warning --context versioner-warnings --append"}
+	if len(*calls) != 1 || (*calls)[0] != want {
+		t.Errorf("Expected %v, got %v", want, *calls)
+	}
+}
+
+func TestReporter_Result(t *testing.T) {
+	r, calls := newTestReporter()
+
+	r.Result(report.Result{Variables: []report.Variable{{Name: "VERSIONER_EVENT_ID", Value: "evt-1"}, {Name: "VERSIONER_ERROR", Value: ""}}})
+
+	want := invocation{"evt-1", "meta-data set VERSIONER_EVENT_ID"}
+	if len(*calls) != 1 || (*calls)[0] != want {
+		t.Errorf("Expected %v, got %v", want, *calls)
+	}
+}
+
+func TestReporterRegistered(t *testing.T) {
+	if len(report.ForSystem(cicd.SystemBuildkite)) == 0 {
+		t.Error("Expected a reporter registered for Buildkite")
+	}
+}
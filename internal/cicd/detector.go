@@ -1,7 +1,9 @@
 package cicd
 
 import (
+	"encoding/hex"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 )
@@ -10,15 +12,26 @@ import (
 type System string
 
 const (
-	SystemGitHub    System = "github"
-	SystemGitLab    System = "gitlab"
-	SystemJenkins   System = "jenkins"
-	SystemCircleCI  System = "circleci"
-	SystemBitbucket System = "bitbucket"
-	SystemAzure     System = "azure-devops"
-	SystemTravis    System = "travis"
-	SystemRundeck   System = "rundeck"
-	SystemUnknown   System = "unknown"
+	SystemGitHub     System = "github"
+	SystemGitLab     System = "gitlab"
+	SystemJenkins    System = "jenkins"
+	SystemCircleCI   System = "circleci"
+	SystemBitbucket  System = "bitbucket"
+	SystemAzure      System = "azure-devops"
+	SystemTravis     System = "travis"
+	SystemBuildkite  System = "buildkite"
+	SystemTeamCity   System = "teamcity"
+	SystemHarness    System = "harness"
+	SystemWoodpecker System = "woodpecker"
+	SystemDrone      System = "drone"
+	SystemCodeBuild  System = "aws-codebuild"
+	SystemCloudBuild System = "google-cloud-build"
+	SystemSemaphore  System = "semaphore"
+	SystemSpinnaker  System = "spinnaker"
+	SystemArgo       System = "argo-workflows"
+	SystemTekton     System = "tekton"
+	SystemRundeck    System = "rundeck"
+	SystemUnknown    System = "unknown"
 )
 
 // DetectedValues holds auto-detected values from CI/CD environment
@@ -32,9 +45,21 @@ type DetectedValues struct {
 	BuildNumber   string
 	BuildURL      string
 	InvokeID      string
+	RunAttempt    string // attempt counter of the CI run, on systems that have one
+	JobID         string // CI job identifier, which changes when the job is retried (GitLab)
 	BuiltBy       string
 	BuiltByEmail  string
 	BuiltByName   string
+	GitDirty      *bool // whether the local git working tree has uncommitted changes; nil if unknown
+}
+
+// RetryID returns a value that changes when the CI run or job is retried: the run attempt
+// counter, or the job ID on systems without one
+func (d *DetectedValues) RetryID() string {
+	if d.RunAttempt != "" {
+		return d.RunAttempt
+	}
+	return d.JobID
 }
 
 // ExtraMetadata returns system-specific metadata with vi_ prefix
@@ -90,6 +115,73 @@ func (d *DetectedValues) ExtraMetadata() map[string]interface{} {
 		addIfPresent(metadata, "vi_travis_job_number", os.Getenv("TRAVIS_JOB_NUMBER"))
 		addIfPresent(metadata, "vi_travis_event_type", os.Getenv("TRAVIS_EVENT_TYPE"))
 
+	case SystemBuildkite:
+		addIfPresent(metadata, "vi_bk_pipeline_slug", os.Getenv("BUILDKITE_PIPELINE_SLUG"))
+		addIfPresent(metadata, "vi_bk_step_key", os.Getenv("BUILDKITE_STEP_KEY"))
+		addIfPresent(metadata, "vi_bk_agent_name", os.Getenv("BUILDKITE_AGENT_NAME"))
+		addIfPresent(metadata, "vi_bk_retry_count", os.Getenv("BUILDKITE_RETRY_COUNT"))
+
+	case SystemTeamCity:
+		addIfPresent(metadata, "vi_tc_project_name", os.Getenv("TEAMCITY_PROJECT_NAME"))
+		addIfPresent(metadata, "vi_tc_buildconf_name", os.Getenv("TEAMCITY_BUILDCONF_NAME"))
+		addIfPresent(metadata, "vi_tc_version", os.Getenv("TEAMCITY_VERSION"))
+
+	case SystemHarness:
+		addIfPresent(metadata, "vi_harness_account_id", os.Getenv("HARNESS_ACCOUNT_ID"))
+		addIfPresent(metadata, "vi_harness_org_id", os.Getenv("HARNESS_ORG_ID"))
+		addIfPresent(metadata, "vi_harness_project_id", os.Getenv("HARNESS_PROJECT_ID"))
+		addIfPresent(metadata, "vi_harness_pipeline_id", os.Getenv("HARNESS_PIPELINE_ID"))
+		addIfPresent(metadata, "vi_harness_stage_id", os.Getenv("HARNESS_STAGE_ID"))
+		addIfPresent(metadata, "vi_harness_step_id", os.Getenv("HARNESS_STEP_ID"))
+
+	case SystemWoodpecker:
+		addIfPresent(metadata, "vi_wp_pipeline_event", os.Getenv("CI_PIPELINE_EVENT"))
+		addIfPresent(metadata, "vi_wp_workflow_name", os.Getenv("CI_WORKFLOW_NAME"))
+		addIfPresent(metadata, "vi_wp_step_name", os.Getenv("CI_STEP_NAME"))
+		addIfPresent(metadata, "vi_wp_forge_url", os.Getenv("CI_PIPELINE_FORGE_URL"))
+
+	case SystemDrone:
+		addIfPresent(metadata, "vi_drone_build_event", os.Getenv("DRONE_BUILD_EVENT"))
+		addIfPresent(metadata, "vi_drone_stage_name", os.Getenv("DRONE_STAGE_NAME"))
+		addIfPresent(metadata, "vi_drone_step_name", os.Getenv("DRONE_STEP_NAME"))
+		addIfPresent(metadata, "vi_drone_commit_link", os.Getenv("DRONE_COMMIT_LINK"))
+
+	case SystemCodeBuild:
+		addIfPresent(metadata, "vi_cb_build_arn", os.Getenv("CODEBUILD_BUILD_ARN"))
+		addIfPresent(metadata, "vi_cb_initiator", os.Getenv("CODEBUILD_INITIATOR"))
+		addIfPresent(metadata, "vi_cb_webhook_event", os.Getenv("CODEBUILD_WEBHOOK_EVENT"))
+		addIfPresent(metadata, "vi_cb_region", os.Getenv("AWS_REGION"))
+
+	case SystemCloudBuild:
+		addIfPresent(metadata, "vi_gcb_project_id", os.Getenv("PROJECT_ID"))
+		addIfPresent(metadata, "vi_gcb_build_id", os.Getenv("BUILD_ID"))
+		addIfPresent(metadata, "vi_gcb_trigger_name", os.Getenv("TRIGGER_NAME"))
+		addIfPresent(metadata, "vi_gcb_location", os.Getenv("LOCATION"))
+
+	case SystemSemaphore:
+		addIfPresent(metadata, "vi_semaphore_project_name", os.Getenv("SEMAPHORE_PROJECT_NAME"))
+		addIfPresent(metadata, "vi_semaphore_pipeline_id", os.Getenv("SEMAPHORE_PIPELINE_ID"))
+		addIfPresent(metadata, "vi_semaphore_job_id", os.Getenv("SEMAPHORE_JOB_ID"))
+		addIfPresent(metadata, "vi_semaphore_job_name", os.Getenv("SEMAPHORE_JOB_NAME"))
+
+	case SystemSpinnaker:
+		addIfPresent(metadata, "vi_spin_application", os.Getenv("SPINNAKER_APPLICATION"))
+		addIfPresent(metadata, "vi_spin_pipeline_name", os.Getenv("SPINNAKER_PIPELINE_NAME"))
+		addIfPresent(metadata, "vi_spin_execution_id", os.Getenv("SPINNAKER_EXECUTION_ID"))
+		addIfPresent(metadata, "vi_spin_stage_name", os.Getenv("SPINNAKER_STAGE_NAME"))
+
+	case SystemArgo:
+		addIfPresent(metadata, "vi_argo_workflow_name", os.Getenv("ARGO_WORKFLOW_NAME"))
+		addIfPresent(metadata, "vi_argo_node_id", os.Getenv("ARGO_NODE_ID"))
+		addIfPresent(metadata, "vi_argo_pod_name", os.Getenv("ARGO_POD_NAME"))
+		addIfPresent(metadata, "vi_argo_container_name", os.Getenv("ARGO_CONTAINER_NAME"))
+
+	case SystemTekton:
+		addIfPresent(metadata, "vi_tekton_pipeline", os.Getenv("TEKTON_PIPELINE"))
+		addIfPresent(metadata, "vi_tekton_pipeline_run", os.Getenv("TEKTON_PIPELINE_RUN"))
+		addIfPresent(metadata, "vi_tekton_task_run", os.Getenv("TEKTON_TASK_RUN"))
+		addIfPresent(metadata, "vi_tekton_namespace", os.Getenv("TEKTON_NAMESPACE"))
+
 	case SystemRundeck:
 		addIfPresent(metadata, "vi_rd_job_id", os.Getenv("RD_JOB_ID"))
 		addIfPresent(metadata, "vi_rd_job_execid", os.Getenv("RD_JOB_EXECID"))
@@ -100,6 +192,10 @@ func (d *DetectedValues) ExtraMetadata() map[string]interface{} {
 		addIfPresent(metadata, "vi_rd_job_url", os.Getenv("RD_JOB_URL"))
 	}
 
+	if d.GitDirty != nil {
+		metadata["vi_git_dirty"] = *d.GitDirty
+	}
+
 	return metadata
 }
 
@@ -131,13 +227,43 @@ func Detect() *DetectedValues {
 		detectAzure(detected)
 	case SystemTravis:
 		detectTravis(detected)
+	case SystemBuildkite:
+		detectBuildkite(detected)
+	case SystemTeamCity:
+		detectTeamCity(detected)
+	case SystemHarness:
+		detectHarness(detected)
+	case SystemWoodpecker:
+		detectWoodpecker(detected)
+	case SystemDrone:
+		detectDrone(detected)
+	case SystemCodeBuild:
+		detectCodeBuild(detected)
+	case SystemCloudBuild:
+		detectCloudBuild(detected)
+	case SystemSemaphore:
+		detectSemaphore(detected)
+	case SystemSpinnaker:
+		detectSpinnaker(detected)
+	case SystemArgo:
+		detectArgo(detected)
+	case SystemTekton:
+		detectTekton(detected)
 	case SystemRundeck:
 		detectRundeck(detected)
 	}
 
+	// Fill anything the CI system didn't provide from the local git repository
+	detectGit(detected)
+
 	return detected
 }
 
+// DetectSystem identifies which CI/CD system is running, without extracting any values
+func DetectSystem() System {
+	return detectSystem()
+}
+
 // detectSystem identifies which CI/CD system is running
 func detectSystem() System {
 	if os.Getenv("GITHUB_ACTIONS") == "true" {
@@ -161,6 +287,40 @@ func detectSystem() System {
 	if os.Getenv("TRAVIS") == "true" {
 		return SystemTravis
 	}
+	if os.Getenv("BUILDKITE") == "true" {
+		return SystemBuildkite
+	}
+	if os.Getenv("TEAMCITY_VERSION") != "" {
+		return SystemTeamCity
+	}
+	// Harness CI and Woodpecker also set Drone's variables, so check them first
+	if os.Getenv("HARNESS_BUILD_ID") != "" {
+		return SystemHarness
+	}
+	if os.Getenv("CI") == "woodpecker" {
+		return SystemWoodpecker
+	}
+	if os.Getenv("DRONE") == "true" {
+		return SystemDrone
+	}
+	if os.Getenv("CODEBUILD_BUILD_ID") != "" {
+		return SystemCodeBuild
+	}
+	if os.Getenv("BUILDER_OUTPUT") != "" {
+		return SystemCloudBuild
+	}
+	if os.Getenv("SEMAPHORE") == "true" {
+		return SystemSemaphore
+	}
+	if os.Getenv("SPINNAKER_EXECUTION_ID") != "" {
+		return SystemSpinnaker
+	}
+	if os.Getenv("ARGO_NODE_ID") != "" {
+		return SystemArgo
+	}
+	if os.Getenv("TEKTON_PIPELINE_RUN") != "" || os.Getenv("TEKTON_TASK_RUN") != "" {
+		return SystemTekton
+	}
 	if os.Getenv("RD_JOB_ID") != "" {
 		return SystemRundeck
 	}
@@ -173,6 +333,7 @@ func detectGitHub(d *DetectedValues) {
 	d.SCMSha = os.Getenv("GITHUB_SHA")
 	d.SCMBranch = os.Getenv("GITHUB_REF_NAME")
 	d.InvokeID = os.Getenv("GITHUB_RUN_ID")
+	d.RunAttempt = os.Getenv("GITHUB_RUN_ATTEMPT")
 	d.BuildNumber = os.Getenv("GITHUB_RUN_NUMBER")
 	d.BuiltBy = os.Getenv("GITHUB_ACTOR")
 
@@ -204,6 +365,7 @@ func detectGitLab(d *DetectedValues) {
 	d.SCMSha = os.Getenv("CI_COMMIT_SHA")
 	d.SCMBranch = os.Getenv("CI_COMMIT_REF_NAME")
 	d.InvokeID = os.Getenv("CI_PIPELINE_ID")
+	d.JobID = os.Getenv("CI_JOB_ID") // GitLab has no attempt counter, but retried jobs get a new ID
 	d.BuildNumber = os.Getenv("CI_PIPELINE_IID")
 	d.BuildURL = os.Getenv("CI_PIPELINE_URL")
 	d.BuiltBy = os.Getenv("GITLAB_USER_LOGIN")
@@ -315,6 +477,7 @@ func detectAzure(d *DetectedValues) {
 	d.SCMBranch = os.Getenv("BUILD_SOURCEBRANCHNAME")
 	d.BuildNumber = os.Getenv("BUILD_BUILDNUMBER")
 	d.InvokeID = os.Getenv("BUILD_BUILDID")
+	d.RunAttempt = os.Getenv("SYSTEM_JOBATTEMPT")
 	d.BuildURL = os.Getenv("BUILD_BUILDURI")
 	d.BuiltBy = os.Getenv("BUILD_REQUESTEDFOR")
 	d.BuiltByEmail = os.Getenv("BUILD_REQUESTEDFOREMAIL")
@@ -359,6 +522,268 @@ func detectTravis(d *DetectedValues) {
 	}
 }
 
+// detectBuildkite extracts values from Buildkite environment
+func detectBuildkite(d *DetectedValues) {
+	d.SCMRepository = normalizeGitURL(os.Getenv("BUILDKITE_REPO"))
+	// BUILDKITE_COMMIT can be "HEAD" before checkout; leave it to the git fallback then
+	d.SCMSha = commitSHA(os.Getenv("BUILDKITE_COMMIT"))
+	d.SCMBranch = os.Getenv("BUILDKITE_BRANCH")
+	if d.SCMBranch == "" {
+		d.SCMBranch = os.Getenv("BUILDKITE_TAG")
+	}
+	d.BuildNumber = os.Getenv("BUILDKITE_BUILD_NUMBER")
+	d.InvokeID = os.Getenv("BUILDKITE_BUILD_ID")
+	d.RunAttempt = os.Getenv("BUILDKITE_RETRY_COUNT")
+	d.BuildURL = os.Getenv("BUILDKITE_BUILD_URL")
+	d.BuiltBy = os.Getenv("BUILDKITE_BUILD_CREATOR")
+	d.BuiltByName = os.Getenv("BUILDKITE_BUILD_CREATOR")
+	d.BuiltByEmail = os.Getenv("BUILDKITE_BUILD_CREATOR_EMAIL")
+
+	// Use repository name as product, falling back to the pipeline slug
+	if d.Product == "" && d.SCMRepository != "" {
+		parts := strings.Split(d.SCMRepository, "/")
+		d.Product = parts[len(parts)-1]
+	}
+	if d.Product == "" {
+		d.Product = os.Getenv("BUILDKITE_PIPELINE_SLUG")
+	}
+
+	// Use SHA as version fallback
+	if d.Version == "" && len(d.SCMSha) >= 8 {
+		d.Version = d.SCMSha[:8]
+	}
+}
+
+// commitSHA returns s if it is a full hexadecimal commit SHA (SHA-1 or SHA-256), or ""
+// for anything else, such as a symbolic ref
+func commitSHA(s string) string {
+	if len(s) != 40 && len(s) != 64 {
+		return ""
+	}
+	if _, err := hex.DecodeString(s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// detectTeamCity extracts values from TeamCity environment
+func detectTeamCity(d *DetectedValues) {
+	d.SCMSha = os.Getenv("BUILD_VCS_NUMBER")
+	d.BuildNumber = os.Getenv("BUILD_NUMBER")
+	d.InvokeID = os.Getenv("BUILD_NUMBER")
+
+	// Use project name as product
+	if d.Product == "" {
+		d.Product = os.Getenv("TEAMCITY_PROJECT_NAME")
+	}
+
+	// Use build number as version fallback
+	if d.Version == "" && d.BuildNumber != "" {
+		d.Version = d.BuildNumber
+	}
+}
+
+// detectHarness extracts values from Harness CI environment, which includes
+// Drone-compatible build variables
+func detectHarness(d *DetectedValues) {
+	detectDrone(d)
+	d.InvokeID = os.Getenv("HARNESS_BUILD_ID")
+	if d.BuildNumber == "" {
+		d.BuildNumber = os.Getenv("HARNESS_BUILD_ID")
+	}
+}
+
+// detectWoodpecker extracts values from Woodpecker CI environment
+func detectWoodpecker(d *DetectedValues) {
+	d.SCMRepository = os.Getenv("CI_REPO")
+	d.SCMSha = os.Getenv("CI_COMMIT_SHA")
+	d.SCMBranch = os.Getenv("CI_COMMIT_BRANCH")
+	if d.SCMBranch == "" {
+		d.SCMBranch = os.Getenv("CI_COMMIT_TAG")
+	}
+	d.BuildNumber = os.Getenv("CI_PIPELINE_NUMBER")
+	d.InvokeID = os.Getenv("CI_PIPELINE_NUMBER")
+	d.BuildURL = os.Getenv("CI_PIPELINE_URL")
+	d.BuiltBy = os.Getenv("CI_COMMIT_AUTHOR")
+	d.BuiltByEmail = os.Getenv("CI_COMMIT_AUTHOR_EMAIL")
+
+	// Use repo name as product
+	if d.Product == "" {
+		d.Product = os.Getenv("CI_REPO_NAME")
+	}
+
+	// Use SHA as version fallback
+	if d.Version == "" && len(d.SCMSha) >= 8 {
+		d.Version = d.SCMSha[:8]
+	}
+}
+
+// detectDrone extracts values from Drone environment
+func detectDrone(d *DetectedValues) {
+	d.SCMRepository = os.Getenv("DRONE_REPO")
+	d.SCMSha = os.Getenv("DRONE_COMMIT_SHA")
+	d.SCMBranch = os.Getenv("DRONE_BRANCH")
+	if d.SCMBranch == "" {
+		d.SCMBranch = os.Getenv("DRONE_TAG")
+	}
+	d.BuildNumber = os.Getenv("DRONE_BUILD_NUMBER")
+	d.InvokeID = os.Getenv("DRONE_BUILD_NUMBER")
+	d.BuildURL = os.Getenv("DRONE_BUILD_LINK")
+	d.BuiltBy = os.Getenv("DRONE_COMMIT_AUTHOR")
+	d.BuiltByEmail = os.Getenv("DRONE_COMMIT_AUTHOR_EMAIL")
+	d.BuiltByName = os.Getenv("DRONE_COMMIT_AUTHOR_NAME")
+
+	// Use repo name as product
+	if d.Product == "" {
+		d.Product = os.Getenv("DRONE_REPO_NAME")
+	}
+
+	// Use SHA as version fallback
+	if d.Version == "" && len(d.SCMSha) >= 8 {
+		d.Version = d.SCMSha[:8]
+	}
+}
+
+// detectCodeBuild extracts values from AWS CodeBuild environment
+func detectCodeBuild(d *DetectedValues) {
+	d.SCMRepository = normalizeGitURL(os.Getenv("CODEBUILD_SOURCE_REPO_URL"))
+	d.SCMSha = os.Getenv("CODEBUILD_RESOLVED_SOURCE_VERSION")
+	d.SCMBranch = strings.TrimPrefix(os.Getenv("CODEBUILD_WEBHOOK_HEAD_REF"), "refs/heads/")
+	d.BuildNumber = os.Getenv("CODEBUILD_BUILD_NUMBER")
+	d.InvokeID = os.Getenv("CODEBUILD_BUILD_ID")
+	d.BuiltBy = os.Getenv("CODEBUILD_INITIATOR")
+
+	// Build URL in the CodeBuild console; the build ID is <project>:<uuid>
+	buildID := os.Getenv("CODEBUILD_BUILD_ID")
+	region := os.Getenv("AWS_REGION")
+	if project, _, ok := strings.Cut(buildID, ":"); ok && region != "" {
+		d.BuildURL = fmt.Sprintf("https://%s.console.aws.amazon.com/codesuite/codebuild/projects/%s/build/%s/?region=%s",
+			region, project, url.PathEscape(buildID), region)
+	}
+
+	// Use repository name as product, falling back to the project name
+	if d.Product == "" && d.SCMRepository != "" {
+		parts := strings.Split(d.SCMRepository, "/")
+		d.Product = parts[len(parts)-1]
+	}
+	if project, _, ok := strings.Cut(buildID, ":"); d.Product == "" && ok {
+		d.Product = project
+	}
+
+	// Use SHA as version fallback
+	if d.Version == "" && len(d.SCMSha) >= 8 {
+		d.Version = d.SCMSha[:8]
+	}
+}
+
+// detectCloudBuild extracts values from Google Cloud Build. Build steps only see
+// substitutions that are mapped into their env, so these values are read under their
+// substitution names (BUILD_ID, COMMIT_SHA, ...).
+func detectCloudBuild(d *DetectedValues) {
+	d.SCMRepository = os.Getenv("REPO_FULL_NAME")
+	if d.SCMRepository == "" {
+		d.SCMRepository = os.Getenv("REPO_NAME")
+	}
+	d.SCMSha = os.Getenv("COMMIT_SHA")
+	d.SCMBranch = os.Getenv("BRANCH_NAME")
+	if d.SCMBranch == "" {
+		d.SCMBranch = os.Getenv("TAG_NAME")
+	}
+	d.InvokeID = os.Getenv("BUILD_ID")
+
+	// Build URL in the Cloud Build console
+	buildID := os.Getenv("BUILD_ID")
+	project := os.Getenv("PROJECT_ID")
+	if buildID != "" && project != "" {
+		location := os.Getenv("LOCATION")
+		if location == "" {
+			location = "global"
+		}
+		d.BuildURL = fmt.Sprintf("https://console.cloud.google.com/cloud-build/builds;region=%s/%s?project=%s", location, buildID, project)
+	}
+
+	// Use repo name as product
+	if d.Product == "" {
+		d.Product = os.Getenv("REPO_NAME")
+	}
+
+	// Use SHA as version fallback
+	if d.Version == "" {
+		d.Version = os.Getenv("SHORT_SHA")
+	}
+	if d.Version == "" && len(d.SCMSha) >= 8 {
+		d.Version = d.SCMSha[:8]
+	}
+}
+
+// detectSemaphore extracts values from Semaphore environment
+func detectSemaphore(d *DetectedValues) {
+	d.SCMRepository = os.Getenv("SEMAPHORE_GIT_REPO_SLUG")
+	d.SCMSha = os.Getenv("SEMAPHORE_GIT_SHA")
+	d.SCMBranch = os.Getenv("SEMAPHORE_GIT_BRANCH")
+	if d.SCMBranch == "" {
+		d.SCMBranch = os.Getenv("SEMAPHORE_GIT_TAG_NAME")
+	}
+	d.BuildNumber = os.Getenv("SEMAPHORE_WORKFLOW_NUMBER")
+	d.InvokeID = os.Getenv("SEMAPHORE_WORKFLOW_ID")
+	d.BuiltBy = os.Getenv("SEMAPHORE_GIT_COMMIT_AUTHOR")
+
+	// Build URL to the workflow
+	orgURL := os.Getenv("SEMAPHORE_ORGANIZATION_URL")
+	workflowID := os.Getenv("SEMAPHORE_WORKFLOW_ID")
+	if orgURL != "" && workflowID != "" {
+		d.BuildURL = fmt.Sprintf("%s/workflows/%s", strings.TrimRight(orgURL, "/"), workflowID)
+	}
+
+	// Use repo name as product
+	if d.Product == "" {
+		d.Product = os.Getenv("SEMAPHORE_GIT_REPO_NAME")
+	}
+
+	// Use SHA as version fallback
+	if d.Version == "" && len(d.SCMSha) >= 8 {
+		d.Version = d.SCMSha[:8]
+	}
+}
+
+// detectSpinnaker extracts values from a Spinnaker Run Job stage. Spinnaker doesn't
+// export its execution context, so these variables are mapped from SpEL expressions
+// in the stage's container env.
+func detectSpinnaker(d *DetectedValues) {
+	d.InvokeID = os.Getenv("SPINNAKER_EXECUTION_ID")
+	d.BuiltBy = os.Getenv("SPINNAKER_TRIGGER_USER")
+
+	// Build URL to the execution in Deck
+	deckURL := os.Getenv("SPINNAKER_URL")
+	application := os.Getenv("SPINNAKER_APPLICATION")
+	executionID := os.Getenv("SPINNAKER_EXECUTION_ID")
+	if deckURL != "" && application != "" {
+		d.BuildURL = fmt.Sprintf("%s/#/applications/%s/executions/details/%s", strings.TrimRight(deckURL, "/"), application, executionID)
+	}
+
+	// Use application as product
+	if d.Product == "" {
+		d.Product = application
+	}
+}
+
+// detectArgo extracts values from an Argo Workflows step
+func detectArgo(d *DetectedValues) {
+	d.InvokeID = os.Getenv("ARGO_WORKFLOW_NAME")
+	if d.InvokeID == "" {
+		d.InvokeID = os.Getenv("ARGO_NODE_ID")
+	}
+}
+
+// detectTekton extracts values from a Tekton step. Tekton doesn't export its run
+// context, so these variables are mapped from context variables in the step's env.
+func detectTekton(d *DetectedValues) {
+	d.InvokeID = os.Getenv("TEKTON_PIPELINE_RUN")
+	if d.InvokeID == "" {
+		d.InvokeID = os.Getenv("TEKTON_TASK_RUN")
+	}
+}
+
 // detectRundeck extracts values from Rundeck environment
 func detectRundeck(d *DetectedValues) {
 	d.BuildNumber = os.Getenv("RD_JOB_EXECID")
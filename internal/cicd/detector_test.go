@@ -2,6 +2,7 @@ package cicd
 
 import (
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -71,7 +72,7 @@ func TestDetectGitLab(t *testing.T) {
 	envVars := []string{
 		"GITLAB_CI", "CI_PROJECT_PATH", "CI_COMMIT_SHA",
 		"CI_COMMIT_REF_NAME", "CI_PIPELINE_ID", "CI_PIPELINE_IID",
-		"CI_PIPELINE_URL", "GITLAB_USER_LOGIN",
+		"CI_PIPELINE_URL", "CI_JOB_ID", "GITLAB_USER_LOGIN",
 		"GITHUB_ACTIONS", "GITHUB_REPOSITORY", "GITHUB_SHA",
 	}
 	for _, key := range envVars {
@@ -96,6 +97,7 @@ func TestDetectGitLab(t *testing.T) {
 	os.Setenv("CI_PIPELINE_ID", "789")
 	os.Setenv("CI_PIPELINE_IID", "123")
 	os.Setenv("CI_PIPELINE_URL", "https://gitlab.com/myorg/my-project/-/pipelines/789")
+	os.Setenv("CI_JOB_ID", "4567")
 	os.Setenv("GITLAB_USER_LOGIN", "testuser")
 
 	detected := Detect()
@@ -111,6 +113,14 @@ func TestDetectGitLab(t *testing.T) {
 	if detected.Product != "my-project" {
 		t.Errorf("Expected product my-project, got %s", detected.Product)
 	}
+
+	// GitLab has no attempt counter; the job ID changes when a job is retried
+	if detected.RunAttempt != "" || detected.JobID != "4567" {
+		t.Errorf("Expected job ID 4567 and no run attempt, got %q and %q", detected.JobID, detected.RunAttempt)
+	}
+	if detected.RetryID() != "4567" {
+		t.Errorf("Expected retry ID 4567, got %s", detected.RetryID())
+	}
 }
 
 func TestDetectRundeck(t *testing.T) {
@@ -176,11 +186,143 @@ func TestDetectRundeck(t *testing.T) {
 	}
 }
 
+func TestDetectBuildkite(t *testing.T) {
+	// Save and clear environment
+	originalEnv := make(map[string]string)
+	envVars := []string{
+		"BUILDKITE", "BUILDKITE_REPO", "BUILDKITE_COMMIT", "BUILDKITE_BRANCH",
+		"BUILDKITE_BUILD_NUMBER", "BUILDKITE_BUILD_ID", "BUILDKITE_BUILD_URL",
+		"BUILDKITE_RETRY_COUNT", "BUILDKITE_BUILD_CREATOR", "BUILDKITE_BUILD_CREATOR_EMAIL",
+		"BUILDKITE_PIPELINE_SLUG", "BUILDKITE_STEP_KEY", "BUILDKITE_AGENT_NAME",
+		"GITHUB_ACTIONS", "GITLAB_CI", "JENKINS_URL", "CIRCLECI",
+		"BITBUCKET_BUILD_NUMBER", "TF_BUILD", "TRAVIS",
+	}
+	for _, key := range envVars {
+		originalEnv[key] = os.Getenv(key)
+		os.Unsetenv(key)
+	}
+	defer func() {
+		for key, val := range originalEnv {
+			if val != "" {
+				os.Setenv(key, val)
+			} else {
+				os.Unsetenv(key)
+			}
+		}
+	}()
+
+	// Set Buildkite environment
+	os.Setenv("BUILDKITE", "true")
+	os.Setenv("BUILDKITE_REPO", "git@github.com:myorg/payments-api.git")
+	os.Setenv("BUILDKITE_COMMIT", "abc123def456789012345678901234567890abcd")
+	os.Setenv("BUILDKITE_BRANCH", "main")
+	os.Setenv("BUILDKITE_BUILD_NUMBER", "1514")
+	os.Setenv("BUILDKITE_BUILD_ID", "f62a1b4d-10f9-4790-bc1c-e2c3a0c80983")
+	os.Setenv("BUILDKITE_BUILD_URL", "https://buildkite.com/myorg/payments/builds/1514")
+	os.Setenv("BUILDKITE_RETRY_COUNT", "1")
+	os.Setenv("BUILDKITE_BUILD_CREATOR", "Jane Doe")
+	os.Setenv("BUILDKITE_BUILD_CREATOR_EMAIL", "jane@example.com")
+	os.Setenv("BUILDKITE_PIPELINE_SLUG", "payments")
+	os.Setenv("BUILDKITE_STEP_KEY", "deploy")
+	os.Setenv("BUILDKITE_AGENT_NAME", "agent-1")
+
+	detected := Detect()
+
+	if detected.System != SystemBuildkite {
+		t.Errorf("Expected system %s, got %s", SystemBuildkite, detected.System)
+	}
+
+	if detected.SCMRepository != "github.com/myorg/payments-api" {
+		t.Errorf("Expected repository github.com/myorg/payments-api, got %s", detected.SCMRepository)
+	}
+
+	if detected.Product != "payments-api" {
+		t.Errorf("Expected product payments-api, got %s", detected.Product)
+	}
+
+	if detected.Version != "abc123de" {
+		t.Errorf("Expected version abc123de, got %s", detected.Version)
+	}
+
+	if detected.SCMBranch != "main" {
+		t.Errorf("Expected branch main, got %s", detected.SCMBranch)
+	}
+
+	if detected.BuildNumber != "1514" {
+		t.Errorf("Expected build number 1514, got %s", detected.BuildNumber)
+	}
+
+	if detected.RunAttempt != "1" {
+		t.Errorf("Expected run attempt 1, got %s", detected.RunAttempt)
+	}
+
+	if detected.BuildURL != "https://buildkite.com/myorg/payments/builds/1514" {
+		t.Errorf("Expected build URL to match, got %s", detected.BuildURL)
+	}
+
+	if detected.BuiltByName != "Jane Doe" || detected.BuiltByEmail != "jane@example.com" {
+		t.Errorf("Expected creator Jane Doe <jane@example.com>, got %s <%s>", detected.BuiltByName, detected.BuiltByEmail)
+	}
+
+	metadata := detected.ExtraMetadata()
+	expected := map[string]string{
+		"vi_bk_pipeline_slug": "payments",
+		"vi_bk_step_key":      "deploy",
+		"vi_bk_agent_name":    "agent-1",
+		"vi_bk_retry_count":   "1",
+	}
+	for key, want := range expected {
+		if metadata[key] != want {
+			t.Errorf("Expected %s=%s, got %v", key, want, metadata[key])
+		}
+	}
+}
+
+func TestDetectBuildkite_SymbolicCommit(t *testing.T) {
+	t.Setenv("BUILDKITE_REPO", "")
+	t.Setenv("BUILDKITE_PIPELINE_SLUG", "payments")
+	t.Setenv("BUILDKITE_COMMIT", "HEAD")
+
+	// "HEAD" is not a SHA: it is left for the git fallback to fill, and not used as version
+	d := &DetectedValues{System: SystemBuildkite}
+	detectBuildkite(d)
+	if d.SCMSha != "" {
+		t.Errorf("Expected no SHA, got %s", d.SCMSha)
+	}
+	if d.Version != "" {
+		t.Errorf("Expected no version, got %s", d.Version)
+	}
+}
+
+func TestCommitSHA(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"abc123def456789012345678901234567890abcd", "abc123def456789012345678901234567890abcd"},
+		{strings.Repeat("0a", 32), strings.Repeat("0a", 32)},
+		{"HEAD", ""},
+		{"abc123d", ""},
+		{"xyz123def456789012345678901234567890abcd", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := commitSHA(tt.input); got != tt.want {
+			t.Errorf("commitSHA(%q) = %q, expected %q", tt.input, got, tt.want)
+		}
+	}
+}
+
 func TestDetectUnknown(t *testing.T) {
+	t.Chdir(t.TempDir())
+
 	// Clear all CI environment variables
 	ciEnvVars := []string{
 		"GITHUB_ACTIONS", "GITLAB_CI", "JENKINS_URL", "CIRCLECI",
-		"BITBUCKET_BUILD_NUMBER", "TF_BUILD", "TRAVIS", "RD_JOB_ID",
+		"BITBUCKET_BUILD_NUMBER", "TF_BUILD", "TRAVIS", "BUILDKITE", "TEAMCITY_VERSION",
+		"HARNESS_BUILD_ID", "CI", "DRONE", "CODEBUILD_BUILD_ID", "BUILDER_OUTPUT", "SEMAPHORE",
+		"SPINNAKER_EXECUTION_ID", "ARGO_NODE_ID", "TEKTON_PIPELINE_RUN", "TEKTON_TASK_RUN", "RD_JOB_ID",
 	}
 	originalEnv := make(map[string]string)
 	for _, key := range ciEnvVars {
@@ -337,11 +479,319 @@ func TestExtraMetadataRundeck(t *testing.T) {
 	}
 }
 
+// detectionEnvVars are the variables detectSystem checks, cleared by tests that
+// select a system
+var detectionEnvVars = []string{
+	"GITHUB_ACTIONS", "GITLAB_CI", "JENKINS_URL", "CIRCLECI", "BITBUCKET_BUILD_NUMBER",
+	"TF_BUILD", "TRAVIS", "BUILDKITE", "TEAMCITY_VERSION", "HARNESS_BUILD_ID", "CI", "DRONE",
+	"CODEBUILD_BUILD_ID", "BUILDER_OUTPUT", "SEMAPHORE", "SPINNAKER_EXECUTION_ID",
+	"ARGO_NODE_ID", "TEKTON_PIPELINE_RUN", "TEKTON_TASK_RUN", "RD_JOB_ID",
+}
+
+func TestDetectAdditionalSystems(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected DetectedValues
+		metadata map[string]string
+	}{
+		{
+			name: "teamcity",
+			env: map[string]string{
+				"TEAMCITY_VERSION":        "2024.03 (build 156364)",
+				"TEAMCITY_PROJECT_NAME":   "payments",
+				"TEAMCITY_BUILDCONF_NAME": "Deploy",
+				"BUILD_NUMBER":            "318",
+				"BUILD_VCS_NUMBER":        "abc123def456789012345678901234567890abcd",
+			},
+			expected: DetectedValues{
+				System:      SystemTeamCity,
+				Product:     "payments",
+				Version:     "318",
+				SCMSha:      "abc123def456789012345678901234567890abcd",
+				BuildNumber: "318",
+				InvokeID:    "318",
+			},
+			metadata: map[string]string{
+				"vi_tc_project_name":   "payments",
+				"vi_tc_buildconf_name": "Deploy",
+				"vi_tc_version":        "2024.03 (build 156364)",
+			},
+		},
+		{
+			name: "harness",
+			env: map[string]string{
+				"HARNESS_BUILD_ID":    "57",
+				"HARNESS_PIPELINE_ID": "deploy_api",
+				"HARNESS_STAGE_ID":    "prod",
+				"DRONE":               "true",
+				"DRONE_REPO":          "myorg/api",
+				"DRONE_REPO_NAME":     "api",
+				"DRONE_COMMIT_SHA":    "abc123def456789012345678901234567890abcd",
+				"DRONE_BRANCH":        "main",
+				"DRONE_BUILD_NUMBER":  "57",
+				"DRONE_BUILD_LINK":    "https://app.harness.io/ng/account/acc/ci/builds/57",
+			},
+			expected: DetectedValues{
+				System:        SystemHarness,
+				Product:       "api",
+				Version:       "abc123de",
+				SCMRepository: "myorg/api",
+				SCMSha:        "abc123def456789012345678901234567890abcd",
+				SCMBranch:     "main",
+				BuildNumber:   "57",
+				BuildURL:      "https://app.harness.io/ng/account/acc/ci/builds/57",
+				InvokeID:      "57",
+			},
+			metadata: map[string]string{
+				"vi_harness_pipeline_id": "deploy_api",
+				"vi_harness_stage_id":    "prod",
+			},
+		},
+		{
+			name: "woodpecker",
+			env: map[string]string{
+				"CI":                     "woodpecker",
+				"CI_REPO":                "myorg/api",
+				"CI_REPO_NAME":           "api",
+				"CI_COMMIT_SHA":          "abc123def456789012345678901234567890abcd",
+				"CI_COMMIT_TAG":          "v1.2.3",
+				"CI_PIPELINE_NUMBER":     "88",
+				"CI_PIPELINE_URL":        "https://ci.example.com/repos/7/pipeline/88",
+				"CI_COMMIT_AUTHOR":       "jdoe",
+				"CI_COMMIT_AUTHOR_EMAIL": "jdoe@example.com",
+				"CI_PIPELINE_EVENT":      "tag",
+				"CI_STEP_NAME":           "deploy",
+			},
+			expected: DetectedValues{
+				System:        SystemWoodpecker,
+				Product:       "api",
+				Version:       "abc123de",
+				SCMRepository: "myorg/api",
+				SCMSha:        "abc123def456789012345678901234567890abcd",
+				SCMBranch:     "v1.2.3",
+				BuildNumber:   "88",
+				BuildURL:      "https://ci.example.com/repos/7/pipeline/88",
+				InvokeID:      "88",
+				BuiltBy:       "jdoe",
+				BuiltByEmail:  "jdoe@example.com",
+			},
+			metadata: map[string]string{
+				"vi_wp_pipeline_event": "tag",
+				"vi_wp_step_name":      "deploy",
+			},
+		},
+		{
+			name: "drone",
+			env: map[string]string{
+				"DRONE":                    "true",
+				"DRONE_REPO":               "myorg/api",
+				"DRONE_REPO_NAME":          "api",
+				"DRONE_COMMIT_SHA":         "abc123def456789012345678901234567890abcd",
+				"DRONE_BRANCH":             "main",
+				"DRONE_BUILD_NUMBER":       "12",
+				"DRONE_BUILD_LINK":         "https://drone.example.com/myorg/api/12",
+				"DRONE_COMMIT_AUTHOR":      "jdoe",
+				"DRONE_COMMIT_AUTHOR_NAME": "Jane Doe",
+				"DRONE_BUILD_EVENT":        "push",
+			},
+			expected: DetectedValues{
+				System:        SystemDrone,
+				Product:       "api",
+				Version:       "abc123de",
+				SCMRepository: "myorg/api",
+				SCMSha:        "abc123def456789012345678901234567890abcd",
+				SCMBranch:     "main",
+				BuildNumber:   "12",
+				BuildURL:      "https://drone.example.com/myorg/api/12",
+				InvokeID:      "12",
+				BuiltBy:       "jdoe",
+				BuiltByName:   "Jane Doe",
+			},
+			metadata: map[string]string{
+				"vi_drone_build_event": "push",
+			},
+		},
+		{
+			name: "aws codebuild",
+			env: map[string]string{
+				"CODEBUILD_BUILD_ID":                "api-deploy:0b4d1c2e-3f4a-5b6c-7d8e-9f0a1b2c3d4e",
+				"CODEBUILD_BUILD_NUMBER":            "204",
+				"CODEBUILD_BUILD_ARN":               "arn:aws:codebuild:eu-west-1:123456789012:build/api-deploy:0b4d1c2e-3f4a-5b6c-7d8e-9f0a1b2c3d4e",
+				"CODEBUILD_SOURCE_REPO_URL":         "https://github.com/myorg/api.git",
+				"CODEBUILD_RESOLVED_SOURCE_VERSION": "abc123def456789012345678901234567890abcd",
+				"CODEBUILD_WEBHOOK_HEAD_REF":        "refs/heads/main",
+				"CODEBUILD_INITIATOR":               "GitHub-Hookshot/abc",
+				"AWS_REGION":                        "eu-west-1",
+			},
+			expected: DetectedValues{
+				System:        SystemCodeBuild,
+				Product:       "api",
+				Version:       "abc123de",
+				SCMRepository: "github.com/myorg/api",
+				SCMSha:        "abc123def456789012345678901234567890abcd",
+				SCMBranch:     "main",
+				BuildNumber:   "204",
+				BuildURL:      "https://eu-west-1.console.aws.amazon.com/codesuite/codebuild/projects/api-deploy/build/api-deploy:0b4d1c2e-3f4a-5b6c-7d8e-9f0a1b2c3d4e/?region=eu-west-1",
+				InvokeID:      "api-deploy:0b4d1c2e-3f4a-5b6c-7d8e-9f0a1b2c3d4e",
+				BuiltBy:       "GitHub-Hookshot/abc",
+			},
+			metadata: map[string]string{
+				"vi_cb_initiator": "GitHub-Hookshot/abc",
+				"vi_cb_region":    "eu-west-1",
+			},
+		},
+		{
+			name: "google cloud build",
+			env: map[string]string{
+				"BUILDER_OUTPUT": "/builder/outputs",
+				"BUILD_ID":       "7c1f9a6e-1234",
+				"PROJECT_ID":     "my-project",
+				"LOCATION":       "europe-west1",
+				"REPO_NAME":      "api",
+				"COMMIT_SHA":     "abc123def456789012345678901234567890abcd",
+				"SHORT_SHA":      "abc123d",
+				"BRANCH_NAME":    "main",
+				"TRIGGER_NAME":   "deploy-main",
+			},
+			expected: DetectedValues{
+				System:        SystemCloudBuild,
+				Product:       "api",
+				Version:       "abc123d",
+				SCMRepository: "api",
+				SCMSha:        "abc123def456789012345678901234567890abcd",
+				SCMBranch:     "main",
+				BuildURL:      "https://console.cloud.google.com/cloud-build/builds;region=europe-west1/7c1f9a6e-1234?project=my-project",
+				InvokeID:      "7c1f9a6e-1234",
+			},
+			metadata: map[string]string{
+				"vi_gcb_project_id":   "my-project",
+				"vi_gcb_trigger_name": "deploy-main",
+			},
+		},
+		{
+			name: "semaphore",
+			env: map[string]string{
+				"SEMAPHORE":                   "true",
+				"SEMAPHORE_GIT_REPO_SLUG":     "myorg/api",
+				"SEMAPHORE_GIT_REPO_NAME":     "api",
+				"SEMAPHORE_GIT_SHA":           "abc123def456789012345678901234567890abcd",
+				"SEMAPHORE_GIT_BRANCH":        "main",
+				"SEMAPHORE_WORKFLOW_ID":       "wf-1",
+				"SEMAPHORE_WORKFLOW_NUMBER":   "31",
+				"SEMAPHORE_ORGANIZATION_URL":  "https://myorg.semaphoreci.com",
+				"SEMAPHORE_GIT_COMMIT_AUTHOR": "jdoe",
+				"SEMAPHORE_JOB_NAME":          "Deploy",
+			},
+			expected: DetectedValues{
+				System:        SystemSemaphore,
+				Product:       "api",
+				Version:       "abc123de",
+				SCMRepository: "myorg/api",
+				SCMSha:        "abc123def456789012345678901234567890abcd",
+				SCMBranch:     "main",
+				BuildNumber:   "31",
+				BuildURL:      "https://myorg.semaphoreci.com/workflows/wf-1",
+				InvokeID:      "wf-1",
+				BuiltBy:       "jdoe",
+			},
+			metadata: map[string]string{
+				"vi_semaphore_job_name": "Deploy",
+			},
+		},
+		{
+			name: "spinnaker",
+			env: map[string]string{
+				"SPINNAKER_EXECUTION_ID":  "01HXYZ",
+				"SPINNAKER_APPLICATION":   "payments",
+				"SPINNAKER_PIPELINE_NAME": "Deploy to prod",
+				"SPINNAKER_URL":           "https://spinnaker.example.com/",
+				"SPINNAKER_TRIGGER_USER":  "jdoe@example.com",
+			},
+			expected: DetectedValues{
+				System:   SystemSpinnaker,
+				Product:  "payments",
+				BuildURL: "https://spinnaker.example.com/#/applications/payments/executions/details/01HXYZ",
+				InvokeID: "01HXYZ",
+				BuiltBy:  "jdoe@example.com",
+			},
+			metadata: map[string]string{
+				"vi_spin_application":   "payments",
+				"vi_spin_pipeline_name": "Deploy to prod",
+				"vi_spin_execution_id":  "01HXYZ",
+			},
+		},
+		{
+			name: "argo workflows",
+			env: map[string]string{
+				"ARGO_NODE_ID":        "deploy-x7k2p-1234567890",
+				"ARGO_WORKFLOW_NAME":  "deploy-x7k2p",
+				"ARGO_CONTAINER_NAME": "main",
+			},
+			expected: DetectedValues{
+				System:   SystemArgo,
+				InvokeID: "deploy-x7k2p",
+			},
+			metadata: map[string]string{
+				"vi_argo_workflow_name":  "deploy-x7k2p",
+				"vi_argo_node_id":        "deploy-x7k2p-1234567890",
+				"vi_argo_container_name": "main",
+			},
+		},
+		{
+			name: "tekton",
+			env: map[string]string{
+				"TEKTON_PIPELINE_RUN": "deploy-run-abc12",
+				"TEKTON_TASK_RUN":     "deploy-run-abc12-deploy",
+				"TEKTON_NAMESPACE":    "ci",
+			},
+			expected: DetectedValues{
+				System:   SystemTekton,
+				InvokeID: "deploy-run-abc12",
+			},
+			metadata: map[string]string{
+				"vi_tekton_pipeline_run": "deploy-run-abc12",
+				"vi_tekton_namespace":    "ci",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Outside a git repository, so nothing is filled in from git
+			t.Chdir(t.TempDir())
+			for _, key := range detectionEnvVars {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+			for key, val := range tt.env {
+				t.Setenv(key, val)
+			}
+
+			detected := Detect()
+			if *detected != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, *detected)
+			}
+
+			metadata := detected.ExtraMetadata()
+			for key, want := range tt.metadata {
+				if metadata[key] != want {
+					t.Errorf("Expected %s=%s, got %v", key, want, metadata[key])
+				}
+			}
+		})
+	}
+}
+
 func TestExtraMetadataUnknownSystem(t *testing.T) {
+	t.Chdir(t.TempDir())
+
 	// Clear all CI environment variables
 	ciEnvVars := []string{
 		"GITHUB_ACTIONS", "GITLAB_CI", "JENKINS_URL", "CIRCLECI",
-		"BITBUCKET_BUILD_NUMBER", "TF_BUILD", "TRAVIS", "RD_JOB_ID",
+		"BITBUCKET_BUILD_NUMBER", "TF_BUILD", "TRAVIS", "BUILDKITE", "TEAMCITY_VERSION",
+		"HARNESS_BUILD_ID", "CI", "DRONE", "CODEBUILD_BUILD_ID", "BUILDER_OUTPUT", "SEMAPHORE",
+		"SPINNAKER_EXECUTION_ID", "ARGO_NODE_ID", "TEKTON_PIPELINE_RUN", "TEKTON_TASK_RUN", "RD_JOB_ID",
 	}
 	originalEnv := make(map[string]string)
 	for _, key := range ciEnvVars {
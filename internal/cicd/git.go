@@ -0,0 +1,366 @@
+package cicd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// GitInfo describes the git repository containing a directory, read directly from its
+// .git directory
+type GitInfo struct {
+	WorkTree   string // top-level directory of the working tree
+	SHA        string // commit checked out at HEAD
+	Branch     string // empty for a detached HEAD
+	Repository string // normalized URL of the origin remote
+	Dirty      *bool  // whether tracked files differ from the index; nil if unknown
+}
+
+// errNotGitRepository is returned when no .git is found above the directory
+var errNotGitRepository = errors.New("not a git repository")
+
+// ReadGit reads the git repository containing dir, without running git
+func ReadGit(dir string) (*GitInfo, error) {
+	workTree, gitDir, err := findGitDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	commonDir := readCommonDir(gitDir)
+
+	info := &GitInfo{WorkTree: workTree}
+
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return nil, err
+	}
+	if ref, ok := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: "); ok {
+		info.Branch = strings.TrimPrefix(ref, "refs/heads/")
+		info.SHA = resolveRef(gitDir, commonDir, ref)
+	} else {
+		info.SHA = strings.TrimSpace(string(head))
+	}
+
+	config := readGitConfig(filepath.Join(commonDir, "config"))
+	if remote := config[`remote "origin".url`]; remote != "" {
+		info.Repository = normalizeGitURL(remote)
+	}
+
+	hashSize := sha1.Size
+	if strings.EqualFold(config["extensions.objectformat"], "sha256") {
+		hashSize = sha256.Size
+	}
+	if dirty, err := indexDirty(workTree, filepath.Join(gitDir, "index"), hashSize); err == nil {
+		info.Dirty = &dirty
+	}
+
+	return info, nil
+}
+
+// detectGit fills values the CI detector left empty from the git repository containing
+// the working directory
+func detectGit(d *DetectedValues) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	info, err := ReadGit(dir)
+	if err != nil {
+		return
+	}
+
+	if d.SCMSha == "" {
+		d.SCMSha = info.SHA
+	}
+	if d.SCMBranch == "" {
+		d.SCMBranch = info.Branch
+	}
+	if d.SCMRepository == "" {
+		d.SCMRepository = info.Repository
+	}
+	d.GitDirty = info.Dirty
+
+	// Use repository name as product, falling back to the working tree's directory name
+	if d.Product == "" && info.Repository != "" {
+		parts := strings.Split(info.Repository, "/")
+		d.Product = parts[len(parts)-1]
+	}
+	if d.Product == "" {
+		d.Product = filepath.Base(info.WorkTree)
+	}
+}
+
+// findGitDir walks up from dir to the first .git, returning the working tree and git
+// directory. A .git file (worktrees, submodules) points to the git directory.
+func findGitDir(dir string) (string, string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	for {
+		path := filepath.Join(dir, ".git")
+		if fi, err := os.Stat(path); err == nil {
+			if fi.IsDir() {
+				return dir, path, nil
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return "", "", err
+			}
+			gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir: ")
+			if !ok {
+				return "", "", errNotGitRepository
+			}
+			if !filepath.IsAbs(gitDir) {
+				gitDir = filepath.Join(dir, gitDir)
+			}
+			return dir, gitDir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", errNotGitRepository
+		}
+		dir = parent
+	}
+}
+
+// readCommonDir returns the directory holding refs and config, which for a linked
+// worktree is named by its commondir file
+func readCommonDir(gitDir string) string {
+	content, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+	commonDir := strings.TrimSpace(string(content))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+	return commonDir
+}
+
+// resolveRef returns the commit a ref points to, from a loose ref file or packed-refs.
+// Symbolic refs are followed a few levels deep.
+func resolveRef(gitDir, commonDir, ref string) string {
+	for depth := 0; depth < 5; depth++ {
+		content, err := os.ReadFile(filepath.Join(gitDir, filepath.FromSlash(ref)))
+		if err != nil {
+			content, err = os.ReadFile(filepath.Join(commonDir, filepath.FromSlash(ref)))
+		}
+		if err != nil {
+			return packedRef(commonDir, ref)
+		}
+
+		value := strings.TrimSpace(string(content))
+		next, ok := strings.CutPrefix(value, "ref: ")
+		if !ok {
+			return value
+		}
+		ref = next
+	}
+	return ""
+}
+
+// packedRef looks up a ref in packed-refs
+func packedRef(commonDir, ref string) string {
+	f, err := os.Open(filepath.Join(commonDir, "packed-refs"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		if sha, name, ok := strings.Cut(line, " "); ok && name == ref {
+			return sha
+		}
+	}
+	return ""
+}
+
+// readGitConfig reads a git config file into "section.key" pairs, with subsections
+// kept quoted (`remote "origin".url`). Section and key names are lowercased.
+func readGitConfig(path string) map[string]string {
+	values := map[string]string{}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return values
+	}
+	defer f.Close()
+
+	var section string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			name := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+			if base, sub, ok := strings.Cut(name, " "); ok {
+				section = strings.ToLower(base) + " " + strings.TrimSpace(sub)
+			} else {
+				section = strings.ToLower(name)
+			}
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, "=")
+		values[section+"."+strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return values
+}
+
+// indexDirty reports whether tracked files in the working tree differ from the index.
+// Files whose size and mtime match the index are assumed unchanged, as git does;
+// others are hashed and compared. Untracked files are not considered.
+func indexDirty(workTree, indexPath string, hashSize int) (bool, error) {
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		return false, err
+	}
+	if len(data) < 12 || string(data[:4]) != "DIRC" {
+		return false, errors.New("invalid index")
+	}
+	version := binary.BigEndian.Uint32(data[4:8])
+	if version < 2 || version > 4 {
+		return false, errors.New("unsupported index version")
+	}
+	count := binary.BigEndian.Uint32(data[8:12])
+
+	pos := 12
+	var previous string
+	for i := uint32(0); i < count; i++ {
+		// ctime, mtime, dev, ino, mode, uid, gid, size, object ID, flags
+		fixed := 40 + hashSize + 2
+		if pos+fixed > len(data) {
+			return false, errors.New("truncated index")
+		}
+		entry := data[pos:]
+		mtimeSec := binary.BigEndian.Uint32(entry[8:12])
+		mtimeNsec := binary.BigEndian.Uint32(entry[12:16])
+		mode := binary.BigEndian.Uint32(entry[24:28])
+		size := binary.BigEndian.Uint32(entry[36:40])
+		objectID := entry[40 : 40+hashSize]
+		flags := binary.BigEndian.Uint16(entry[40+hashSize:])
+
+		skipWorktree := false
+		if flags&0x4000 != 0 && version >= 3 {
+			if pos+fixed+2 > len(data) {
+				return false, errors.New("truncated index")
+			}
+			skipWorktree = binary.BigEndian.Uint16(entry[fixed:])&0x4000 != 0
+			fixed += 2
+		}
+
+		var name string
+		if version == 4 {
+			// Path is the previous path with a number of bytes stripped, plus a suffix
+			strip, n := readOffset(data[pos+fixed:])
+			if n == 0 || strip > len(previous) {
+				return false, errors.New("invalid index entry")
+			}
+			end := bytes.IndexByte(data[pos+fixed+n:], 0)
+			if end < 0 {
+				return false, errors.New("truncated index")
+			}
+			name = previous[:len(previous)-strip] + string(data[pos+fixed+n:pos+fixed+n+end])
+			pos += fixed + n + end + 1
+		} else {
+			end := bytes.IndexByte(data[pos+fixed:], 0)
+			if end < 0 {
+				return false, errors.New("truncated index")
+			}
+			name = string(data[pos+fixed : pos+fixed+end])
+			// Entries are NUL-padded to a multiple of 8 bytes
+			pos += (fixed + end + 8) &^ 7
+		}
+		previous = name
+
+		// Unmerged paths leave the tree dirty
+		if flags&0x3000 != 0 {
+			return true, nil
+		}
+		// Submodules and sparse-checkout entries have nothing to compare
+		if mode&0170000 == 0160000 || skipWorktree {
+			continue
+		}
+
+		if changed, err := entryChanged(filepath.Join(workTree, filepath.FromSlash(name)), mode, size, mtimeSec, mtimeNsec, objectID, hashSize); err != nil || changed {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// entryChanged reports whether a working tree file differs from its index entry
+func entryChanged(path string, mode, size, mtimeSec, mtimeNsec uint32, objectID []byte, hashSize int) (bool, error) {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return true, err
+	}
+	if uint32(fi.Size()) != size {
+		return true, nil
+	}
+	mtime := fi.ModTime()
+	if uint32(mtime.Unix()) == mtimeSec && uint32(mtime.Nanosecond()) == mtimeNsec {
+		return false, nil
+	}
+
+	var content []byte
+	if mode&0170000 == 0120000 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return true, err
+		}
+		content = []byte(target)
+	} else {
+		content, err = os.ReadFile(path)
+		if err != nil {
+			return true, err
+		}
+	}
+
+	var h hash.Hash
+	if hashSize == sha256.Size {
+		h = sha256.New()
+	} else {
+		h = sha1.New()
+	}
+	h.Write([]byte("blob " + strconv.Itoa(len(content)) + "\x00"))
+	h.Write(content)
+
+	return !bytes.Equal(h.Sum(nil), objectID), nil
+}
+
+// readOffset decodes git's variable-length offset encoding, returning the value and
+// the number of bytes read (0 if the input is truncated)
+func readOffset(b []byte) (int, int) {
+	if len(b) == 0 {
+		return 0, 0
+	}
+	val := int(b[0] & 0x7f)
+	n := 1
+	for b[n-1]&0x80 != 0 {
+		if n >= len(b) {
+			return 0, 0
+		}
+		val = ((val + 1) << 7) | int(b[n]&0x7f)
+		n++
+	}
+	return val, n
+}
@@ -0,0 +1,202 @@
+package cicd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// writeFiles creates files under dir from a map of relative paths to contents
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadGit(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		subdir   string
+		expected GitInfo
+	}{
+		{
+			name: "loose ref",
+			files: map[string]string{
+				".git/HEAD":            "ref: refs/heads/main\n",
+				".git/refs/heads/main": "abc123def456789012345678901234567890abcd\n",
+				".git/config":          "[core]\n\tbare = false\n[remote \"origin\"]\n\turl = git@github.com:myorg/payments-api.git\n\tfetch = +refs/heads/*:refs/remotes/origin/*\n",
+			},
+			subdir: "services/api",
+			expected: GitInfo{
+				SHA:        "abc123def456789012345678901234567890abcd",
+				Branch:     "main",
+				Repository: "github.com/myorg/payments-api",
+			},
+		},
+		{
+			name: "packed ref",
+			files: map[string]string{
+				".git/HEAD":        "ref: refs/heads/release/1.2\n",
+				".git/packed-refs": "# pack-refs with: peeled fully-peeled sorted\n1111111111111111111111111111111111111111 refs/heads/main\n2222222222222222222222222222222222222222 refs/heads/release/1.2\n3333333333333333333333333333333333333333 refs/tags/v1.2.0\n^2222222222222222222222222222222222222222\n",
+			},
+			expected: GitInfo{
+				SHA:    "2222222222222222222222222222222222222222",
+				Branch: "release/1.2",
+			},
+		},
+		{
+			name: "detached head",
+			files: map[string]string{
+				".git/HEAD": "abc123def456789012345678901234567890abcd\n",
+			},
+			expected: GitInfo{SHA: "abc123def456789012345678901234567890abcd"},
+		},
+		{
+			name: "linked worktree",
+			files: map[string]string{
+				"main/.git/HEAD":                        "ref: refs/heads/main\n",
+				"main/.git/refs/heads/feature":          "4444444444444444444444444444444444444444\n",
+				"main/.git/config":                      "[remote \"origin\"]\n\turl = https://gitlab.com/myorg/api.git\n",
+				"main/.git/worktrees/feature/HEAD":      "ref: refs/heads/feature\n",
+				"main/.git/worktrees/feature/commondir": "../..\n",
+				"feature/.git":                          "gitdir: ../main/.git/worktrees/feature\n",
+			},
+			subdir: "feature",
+			expected: GitInfo{
+				SHA:        "4444444444444444444444444444444444444444",
+				Branch:     "feature",
+				Repository: "gitlab.com/myorg/api",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			start := filepath.Join(dir, filepath.FromSlash(tt.subdir))
+			if err := os.MkdirAll(start, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			info, err := ReadGit(start)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if info.SHA != tt.expected.SHA || info.Branch != tt.expected.Branch || info.Repository != tt.expected.Repository {
+				t.Errorf("Expected %+v, got %+v", tt.expected, *info)
+			}
+			// No index, so the working tree state is unknown
+			if info.Dirty != nil {
+				t.Errorf("Expected unknown dirty state without an index, got %v", *info.Dirty)
+			}
+		})
+	}
+}
+
+func TestReadGit_NotARepository(t *testing.T) {
+	if _, err := ReadGit(t.TempDir()); err == nil {
+		t.Error("Expected an error outside a git repository")
+	}
+}
+
+func TestReadGit_Dirty(t *testing.T) {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command(gitPath, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	git("init", "-q", "-b", "main")
+	writeFiles(t, dir, map[string]string{"README.md": "hello\n", "src/main.go": "package main\n"})
+	git("add", ".")
+	git("-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial")
+
+	info, err := ReadGit(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if info.Dirty == nil || *info.Dirty {
+		t.Fatalf("Expected a clean working tree, got %v", info.Dirty)
+	}
+	if len(info.SHA) != 40 || info.Branch != "main" {
+		t.Errorf("Expected a commit on main, got %+v", *info)
+	}
+
+	// Untracked files don't count
+	writeFiles(t, dir, map[string]string{"notes.txt": "scratch\n"})
+	if info, _ := ReadGit(dir); info.Dirty == nil || *info.Dirty {
+		t.Error("Expected untracked files to leave the working tree clean")
+	}
+
+	// Same size, so the content has to be hashed
+	writeFiles(t, dir, map[string]string{"README.md": "howdy\n"})
+	if info, _ := ReadGit(dir); info.Dirty == nil || !*info.Dirty {
+		t.Error("Expected a modified file to make the working tree dirty")
+	}
+
+	git("checkout", "-q", "--", "README.md")
+	writeFiles(t, dir, map[string]string{"src/main.go": "package main\n\nfunc main() {}\n"})
+	if info, _ := ReadGit(dir); info.Dirty == nil || !*info.Dirty {
+		t.Error("Expected a modified file to make the working tree dirty")
+	}
+}
+
+func TestDetect_GitFallback(t *testing.T) {
+	for _, key := range detectionEnvVars {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".git/HEAD":            "ref: refs/heads/main\n",
+		".git/refs/heads/main": "abc123def456789012345678901234567890abcd\n",
+		".git/config":          "[remote \"origin\"]\n\turl = https://github.com/myorg/payments-api.git\n",
+	})
+	t.Chdir(dir)
+
+	detected := Detect()
+
+	if detected.System != SystemUnknown {
+		t.Errorf("Expected system %s, got %s", SystemUnknown, detected.System)
+	}
+	if detected.SCMSha != "abc123def456789012345678901234567890abcd" || detected.SCMBranch != "main" {
+		t.Errorf("Expected SHA and branch from git, got %s on %s", detected.SCMSha, detected.SCMBranch)
+	}
+	if detected.SCMRepository != "github.com/myorg/payments-api" || detected.Product != "payments-api" {
+		t.Errorf("Expected repository and product from origin, got %s / %s", detected.SCMRepository, detected.Product)
+	}
+
+	// Values from a CI system take precedence
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_REPOSITORY", "myorg/other")
+	t.Setenv("GITHUB_SHA", "ffffffffffffffffffffffffffffffffffffffff")
+	t.Setenv("GITHUB_REF_NAME", "")
+	detected = Detect()
+	if detected.SCMSha != "ffffffffffffffffffffffffffffffffffffffff" || detected.SCMRepository != "myorg/other" {
+		t.Errorf("Expected CI values to win, got %s / %s", detected.SCMSha, detected.SCMRepository)
+	}
+	if detected.SCMBranch != "main" {
+		t.Errorf("Expected the branch gap to be filled from git, got %q", detected.SCMBranch)
+	}
+}
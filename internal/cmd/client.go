@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/spool"
+)
+
+// newAPIClient creates an API client from the global API configuration and the
+// command's --fail-on-api-error flag
+func newAPIClient(cmd *cobra.Command) (*api.Client, error) {
+	// Get API configuration
+	apiURL := viper.GetString("api_url")
+	apiKey := viper.GetString("api_key")
+
+	if apiKey == "" {
+		return nil, fmt.Errorf("API key is required. Set VERSIONER_API_KEY environment variable or use --api-key flag")
+	}
+
+	failOnApiError := failOnAPIError(cmd)
+
+	// Get retry policy (flags, env vars and config file override the defaults)
+	retry := api.RetryPolicy{
+		MaxAttempts: viper.GetInt("retry_max_attempts"),
+		BaseBackoff: viper.GetDuration("retry_base_backoff"),
+		MaxBackoff:  viper.GetDuration("retry_max_backoff"),
+		Jitter:      viper.GetFloat64("retry_jitter"),
+		Deadline:    viper.GetDuration("retry_deadline"),
+	}
+	if err := retry.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid retry configuration: %w", err)
+	}
+
+	client := api.NewClient(apiURL, apiKey, debug, failOnApiError)
+	client.Verbose = verbose
+	if activeProfile != "" {
+		client.UserAgent += " profile/" + activeProfile
+	}
+	client.Retry = retry
+
+	// Queue undeliverable events instead of dropping them when API errors are tolerated
+	if !failOnApiError && viper.GetBool("spool") {
+		s, err := openSpool()
+		if err != nil {
+			return nil, err
+		}
+		client.Spool = s
+	}
+
+	return client, nil
+}
+
+// failOnAPIError returns the --fail-on-api-error flag, or else the viper config value,
+// or else the project config's override for the command's environment (default: true)
+func failOnAPIError(cmd *cobra.Command) bool {
+	if flag := cmd.Flags().Lookup("fail-on-api-error"); flag != nil && flag.Changed {
+		fail, _ := cmd.Flags().GetBool("fail-on-api-error")
+		return fail
+	}
+	if viper.IsSet("fail_on_api_error") {
+		return viper.GetBool("fail_on_api_error")
+	}
+	if env := project.Environment(eventEnvironment(cmd)); env != nil && env.FailOnAPIError != nil {
+		return *env.FailOnAPIError
+	}
+	return true
+}
+
+// openSpool returns the spool for the configured (or default) spool directory
+func openSpool() (*spool.Spool, error) {
+	dir := viper.GetString("spool_dir")
+	if dir == "" {
+		var err error
+		dir, err = spool.DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return spool.New(dir), nil
+}
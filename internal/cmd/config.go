@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage CLI configuration",
+	Long: `Manage the Versioner CLI configuration file ($HOME/.versioner/config.yaml, or the
+file given with --config).
+Use 'config init' to create the config file.
+Use 'config view' to show the effective configuration and where each value comes from.
+Use 'config get' and 'config set' to read and change single settings.
+Use 'config validate' to check the config files for errors and unknown keys.
+Use 'config profiles' to manage named profiles for multiple Versioner accounts and API endpoints.`,
+	// Config commands read the config file themselves, and must work even when the
+	// selected profile doesn't exist (e.g. to switch to another one)
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Arguments were accepted, so errors from here on are about the configuration
+		cmd.SilenceUsage = true
+		var err error
+		outputFormat, err = parseOutputFormat(viper.GetString("output"))
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/terminal"
+	"github.com/versioner-io/versioner-cli/internal/userconfig"
+)
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create the config file",
+	Long: `Create the config file with your API key and endpoints, readable only by you.
+
+On a terminal, you are prompted for each value (the API key is not echoed). Otherwise,
+or with --non-interactive, the values are taken from flags and environment variables
+(--api-key or VERSIONER_API_KEY, --api-url, --ui-url). Endpoints left at their
+defaults are not written, so later changes to the defaults apply.
+
+With --profile, the values are written to that profile's section, leaving the rest of
+the file untouched. The top-level settings are not copied into the profile: its values
+come only from flags, environment variables or the prompts.`,
+	Example: `  # Prompt for the API key
+  versioner config init
+
+  # In a provisioning script
+  VERSIONER_API_KEY=... versioner config init --non-interactive
+
+  # Add a profile for a staging instance
+  versioner --profile=staging config init --api-url=https://api.staging.versioner.io`,
+	Args: cobra.NoArgs,
+	RunE: runConfigInit,
+}
+
+func init() {
+	configCmd.AddCommand(configInitCmd)
+
+	configInitCmd.Flags().Bool("non-interactive", false, "Don't prompt; take values from flags and environment variables")
+	configInitCmd.Flags().Bool("force", false, "Overwrite the settings of an existing config file or profile")
+}
+
+// initValues are the settings written by 'config init'
+type initValues struct {
+	APIKey string
+	APIURL string
+	UIURL  string
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+	nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
+
+	doc, err := userconfig.Load(writableConfigFile())
+	if err != nil {
+		return err
+	}
+
+	values := &initValues{
+		APIKey: viper.GetString("api_key"),
+		APIURL: viper.GetString("api_url"),
+		UIURL:  viper.GetString("ui_url"),
+	}
+
+	prefix := ""
+	profile, _ := cmd.Flags().GetString("profile")
+	if profile != "" && cmd.Flags().Changed("profile") {
+		prefix = profilesKey + "." + profile + "."
+		var profiles profileFile
+		if err := doc.Decode(&profiles); err != nil {
+			return fmt.Errorf("%s: %w", doc.Path, err)
+		}
+		if _, _, exists := profiles.profile(profile); exists && !force {
+			return fmt.Errorf("profile %q already exists in %s (use --force to overwrite its settings, or 'versioner config set')", profile, doc.Path)
+		}
+		values = profileInitValues(cmd)
+	} else if _, err := os.Stat(doc.Path); err == nil && !force {
+		return fmt.Errorf("%s already exists (use --force to overwrite its settings, or 'versioner config set')", doc.Path)
+	}
+
+	if !nonInteractive && terminal.IsTerminal(os.Stdin) {
+		p := &configPrompter{ctx: cmd.Context(), in: os.Stdin, out: os.Stderr, readSecret: func() (string, error) { return terminal.ReadSecret(cmd.Context(), os.Stdin) }}
+		if err := p.promptInit(values); err != nil {
+			exitPromptCancelled(err)
+			return err
+		}
+	} else if values.APIKey == "" {
+		return fmt.Errorf("API key is required. Set VERSIONER_API_KEY environment variable or use --api-key flag")
+	}
+
+	settings := [][2]string{{"api_key", values.APIKey}}
+	if values.APIURL != defaultAPIURL {
+		settings = append(settings, [2]string{"api_url", values.APIURL})
+	}
+	if values.UIURL != defaultUIURL {
+		settings = append(settings, [2]string{"ui_url", values.UIURL})
+	}
+	for _, setting := range settings {
+		key, _ := lookupConfigKey(setting[0])
+		value, err := key.parse(setting[1])
+		if err != nil {
+			return err
+		}
+		if err := doc.Set(prefix+key.Name, value); err != nil {
+			return err
+		}
+	}
+
+	if err := doc.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Wrote %s\n", doc.Path)
+	if prefix != "" {
+		fmt.Fprintf(os.Stderr, "  Use it with --profile=%s, or make it the default with 'versioner config profiles use %s'\n", profile, profile)
+	}
+	fmt.Fprintf(os.Stderr, "  Check the effective configuration with 'versioner config view'\n")
+	return nil
+}
+
+// profileInitValues returns the starting values for a profile: only those given by flags
+// or environment variables. The profile isn't applied under config commands, so viper's
+// values are the top-level settings, which may belong to another account.
+func profileInitValues(cmd *cobra.Command) *initValues {
+	values := &initValues{APIURL: defaultAPIURL, UIURL: defaultUIURL}
+	for name, value := range map[string]*string{"api_key": &values.APIKey, "api_url": &values.APIURL, "ui_url": &values.UIURL} {
+		key, _ := lookupConfigKey(name)
+		if flag := cmd.Flags().Lookup(key.flagName()); flag != nil && flag.Changed {
+			*value = flag.Value.String()
+		} else if envSet(key) {
+			*value = os.Getenv(key.envName())
+		}
+	}
+	return values
+}
+
+// configPrompter asks for config values, reading one answer per line until ctx is
+// cancelled
+type configPrompter struct {
+	ctx        context.Context
+	in         io.Reader
+	out        io.Writer
+	readSecret func() (string, error)
+}
+
+// promptInit asks for the values written by 'config init', offering the current ones
+// as defaults
+func (p *configPrompter) promptInit(values *initValues) error {
+	for {
+		label := "API key"
+		if values.APIKey != "" {
+			label += " (leave empty to keep " + redactSecret(values.APIKey) + ")"
+		}
+		fmt.Fprintf(p.out, "%s: ", label)
+		answer, err := p.readSecret()
+		if err != nil {
+			return fmt.Errorf("failed to read API key: %w", err)
+		}
+		if answer != "" {
+			values.APIKey = answer
+		}
+		if values.APIKey != "" {
+			break
+		}
+		fmt.Fprintf(p.out, "An API key is required (create one in the Versioner UI under Settings → API Keys)\n")
+	}
+
+	var err error
+	if values.APIURL, err = p.ask("API URL", values.APIURL); err != nil {
+		return err
+	}
+	if values.UIURL, err = p.ask("UI URL", values.UIURL); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ask prompts for a value, returning def if the answer is empty
+func (p *configPrompter) ask(label, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+
+	answer, err := terminal.ReadLine(p.ctx, p.in)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", label, err)
+	}
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// exitPromptCancelled exits with the cancelled exit code if err is from a prompt
+// interrupted by Ctrl-C
+func exitPromptCancelled(err error) {
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "Cancelled\n")
+		os.Exit(exitCodeCancelled)
+	}
+}
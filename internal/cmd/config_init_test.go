@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestPromptInit(t *testing.T) {
+	secrets := []string{"", "sk_live_abcdef123456"}
+	var out bytes.Buffer
+	p := &configPrompter{
+		ctx: context.Background(),
+		in:  strings.NewReader("\nhttps://app.staging.versioner.io\n"),
+		out: &out,
+		readSecret: func() (string, error) {
+			secret := secrets[0]
+			secrets = secrets[1:]
+			return secret, nil
+		},
+	}
+
+	values := &initValues{APIURL: defaultAPIURL, UIURL: defaultUIURL}
+	if err := p.promptInit(values); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := initValues{APIKey: "sk_live_abcdef123456", APIURL: defaultAPIURL, UIURL: "https://app.staging.versioner.io"}
+	if *values != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *values)
+	}
+	if !strings.Contains(out.String(), "An API key is required") {
+		t.Error("Expected an empty API key to be asked again")
+	}
+	if !strings.Contains(out.String(), "API URL ["+defaultAPIURL+"]: ") {
+		t.Errorf("Expected the default API URL to be offered, got %q", out.String())
+	}
+}
+
+func TestPromptInit_KeepsExistingKey(t *testing.T) {
+	var out bytes.Buffer
+	p := &configPrompter{
+		ctx:        context.Background(),
+		in:         strings.NewReader("\n\n"),
+		out:        &out,
+		readSecret: func() (string, error) { return "", nil },
+	}
+
+	values := &initValues{APIKey: "sk_live_existing9876", APIURL: defaultAPIURL, UIURL: defaultUIURL}
+	if err := p.promptInit(values); err != nil {
+		t.Fatal(err)
+	}
+	if values.APIKey != "sk_live_existing9876" {
+		t.Errorf("Expected the existing key to be kept, got %q", values.APIKey)
+	}
+	if strings.Contains(out.String(), "sk_live_existing9876") || !strings.Contains(out.String(), "****9876") {
+		t.Error("Expected the existing key to be redacted in the prompt")
+	}
+}
+
+func TestRunConfigInit_NewProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("api_key: sk_live_default1234\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfgFile = path
+	t.Cleanup(func() { cfgFile = "" })
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VERSIONER_API_KEY", "")
+
+	newCommand := func(flags map[string]string) *cobra.Command {
+		c := &cobra.Command{Use: "init"}
+		c.Flags().String("profile", "", "")
+		c.Flags().String("api-key", "", "")
+		c.Flags().String("api-url", "", "")
+		c.Flags().String("ui-url", "", "")
+		c.Flags().Bool("non-interactive", false, "")
+		c.Flags().Bool("force", false, "")
+		for flag, value := range flags {
+			if err := c.Flags().Set(flag, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+		c.SetContext(context.Background())
+		return c
+	}
+
+	// The top-level API key is not used for the new profile
+	c := newCommand(map[string]string{"profile": "staging", "non-interactive": "true", "api-url": "https://api.staging.versioner.io"})
+	if err := runConfigInit(c, nil); err == nil {
+		t.Fatal("Expected an error without an API key for the profile")
+	}
+
+	c = newCommand(map[string]string{"profile": "staging", "non-interactive": "true", "api-url": "https://api.staging.versioner.io", "api-key": "sk_live_staging5678"})
+	if err := runConfigInit(c, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	_, profiles, err := loadProfiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, staging, ok := profiles.profile("staging")
+	if !ok {
+		t.Fatal("Expected the staging profile to be written")
+	}
+	if staging["api_key"] != "sk_live_staging5678" || staging["api_url"] != "https://api.staging.versioner.io" {
+		t.Errorf("Expected the staging key and URL, got %v", staging)
+	}
+	if _, ok := staging["ui_url"]; ok {
+		t.Errorf("Expected the default UI URL not to be written, got %v", staging)
+	}
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/versioner-io/versioner-cli/internal/versionsource"
+)
+
+// Types of config settings
+const (
+	settingString   = "string"
+	settingBool     = "bool"
+	settingInt      = "int"
+	settingFloat    = "float"
+	settingDuration = "duration"
+	settingList     = "list"
+)
+
+// configKey describes a setting that can be stored in the config file
+type configKey struct {
+	Name        string
+	Type        string
+	Description string
+	Secret      bool
+}
+
+// configKeys are the settings read from the config file, in the order 'config view'
+// shows them. Each can also be set with a VERSIONER_ environment variable.
+var configKeys = []configKey{
+	{"api_url", settingString, "Versioner API URL", false},
+	{"api_key", settingString, "Versioner API key", true},
+	{"ui_url", settingString, "Versioner UI URL", false},
+	{"profile", settingString, "Profile used when --profile is not given", false},
+	{"output", settingString, "Output format: text, json, yaml or env", false},
+	{"fail_on_api_error", settingBool, "Fail commands if the API is unreachable or rejects a request", false},
+	{"retry_max_attempts", settingInt, "Maximum number of API request attempts", false},
+	{"retry_base_backoff", settingDuration, "Wait before the first retry", false},
+	{"retry_max_backoff", settingDuration, "Maximum wait between retries", false},
+	{"retry_jitter", settingFloat, "Fraction of each backoff to randomize, 0-1", false},
+	{"retry_deadline", settingDuration, "Total time allowed for all attempts of a request", false},
+	{"spool", settingBool, "Queue events that cannot be delivered", false},
+	{"spool_dir", settingString, "Directory for queued events", false},
+	{"product", settingString, "Product name", false},
+	{"environment", settingString, "Environment name", false},
+	{"version", settingString, "Version string", false},
+	{"version_from", settingList, "Sources to derive the version from", false},
+	{"status", settingString, "Event status", false},
+	{"source_system", settingString, "Source system", false},
+	{"build_number", settingString, "Build number", false},
+	{"build_url", settingString, "Link to the CI/CD build run", false},
+	{"deploy_url", settingString, "Link to the deployment run", false},
+	{"invoke_id", settingString, "Invocation/run ID", false},
+	{"scm_sha", settingString, "Git commit SHA", false},
+	{"scm_branch", settingString, "Git branch name", false},
+	{"scm_repository", settingString, "Source control repository", false},
+	{"built_by", settingString, "Build user identifier", false},
+	{"built_by_email", settingString, "Build user email", false},
+	{"built_by_name", settingString, "Build user display name", false},
+	{"deployed_by", settingString, "Deployment user identifier", false},
+	{"deployed_by_email", settingString, "Deployment user email", false},
+	{"deployed_by_name", settingString, "Deployment user display name", false},
+	{"github_deployments", settingBool, "Mirror deployment events as GitHub Deployments", false},
+	{"github_pr_comment", settingBool, "Comment on pull requests included in a deployment", false},
+}
+
+// profilesKey is the config file section holding named profiles
+const profilesKey = "profiles"
+
+// lookupConfigKey returns the setting named key
+func lookupConfigKey(key string) (configKey, bool) {
+	for _, k := range configKeys {
+		if k.Name == key {
+			return k, true
+		}
+	}
+	return configKey{}, false
+}
+
+// envName returns the environment variable for a setting
+func (k configKey) envName() string {
+	return "VERSIONER_" + strings.ToUpper(k.Name)
+}
+
+// flagName returns the flag for a setting
+func (k configKey) flagName() string {
+	return strings.ReplaceAll(k.Name, "_", "-")
+}
+
+// parse converts a value given on the command line to the setting's type, for writing
+// to the config file
+func (k configKey) parse(value string) (interface{}, error) {
+	var parsed interface{}
+	var err error
+	switch k.Type {
+	case settingBool:
+		parsed, err = strconv.ParseBool(value)
+	case settingInt:
+		parsed, err = strconv.Atoi(value)
+	case settingFloat:
+		parsed, err = strconv.ParseFloat(value, 64)
+	case settingDuration:
+		// Stored as written (e.g. "30s"), which is how viper reads durations
+		_, err = time.ParseDuration(value)
+		parsed = value
+	case settingList:
+		parsed = versionsource.ParseSources([]string{value})
+	default:
+		parsed = value
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value %q for %s", k.Type, value, k.Name)
+	}
+
+	if problem := k.check(parsed); problem != "" {
+		return nil, fmt.Errorf("%s: %s", k.Name, problem)
+	}
+	return parsed, nil
+}
+
+// check validates a value read from the config file, returning a description of the
+// problem or ""
+func (k configKey) check(value interface{}) string {
+	switch k.Type {
+	case settingBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("expected true or false, got %v", value)
+		}
+	case settingInt:
+		if _, ok := value.(int); !ok {
+			return fmt.Sprintf("expected a whole number, got %v", value)
+		}
+	case settingFloat:
+		switch value.(type) {
+		case int, float64:
+		default:
+			return fmt.Sprintf("expected a number, got %v", value)
+		}
+	case settingDuration:
+		s, ok := value.(string)
+		if _, err := time.ParseDuration(s); !ok || err != nil {
+			return fmt.Sprintf("expected a duration such as 30s or 2m, got %v", value)
+		}
+	case settingList:
+		var sources []string
+		switch v := value.(type) {
+		case string:
+			sources = versionsource.ParseSources([]string{v})
+		case []string:
+			sources = v
+		case []interface{}:
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return fmt.Sprintf("expected a list of strings, got %v", value)
+				}
+				sources = append(sources, s)
+			}
+		default:
+			return fmt.Sprintf("expected a list, got %v", value)
+		}
+		for _, source := range sources {
+			if !versionsource.IsValid(source) {
+				return fmt.Sprintf("unknown version source %q", source)
+			}
+		}
+	default:
+		switch value.(type) {
+		case map[string]interface{}, []interface{}, nil:
+			return fmt.Sprintf("expected a single value, got %v", value)
+		}
+	}
+
+	s := fmt.Sprint(value)
+	switch k.Name {
+	case "api_url", "ui_url":
+		if u, err := url.Parse(s); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Sprintf("expected an http(s) URL, got %q", s)
+		}
+	case "output":
+		if err := validateOutputFormat(s); err != nil {
+			return err.Error()
+		}
+	case "retry_max_attempts":
+		if n, _ := value.(int); n < 1 {
+			return "must be at least 1"
+		}
+	case "retry_jitter":
+		if f, _ := strconv.ParseFloat(s, 64); f < 0 || f > 1 {
+			return "must be between 0 and 1"
+		}
+	}
+	return ""
+}
+
+// configProblem is an error found in a config file
+type configProblem struct {
+	File    string `json:"file"`
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message"`
+}
+
+// validateSettings checks the settings of a config file, including its profiles
+func validateSettings(file string, settings map[string]interface{}) []configProblem {
+	var problems []configProblem
+
+	problems = append(problems, checkSettings(file, "", settings)...)
+
+	profiles := map[string]interface{}{}
+	if raw, ok := settings[profilesKey]; ok && raw != nil {
+		if p, ok := raw.(map[string]interface{}); ok {
+			profiles = p
+		} else {
+			problems = append(problems, configProblem{file, profilesKey, "expected a mapping of profile names to settings"})
+		}
+	}
+	for _, name := range sortedSettingKeys(profiles) {
+		switch p := profiles[name].(type) {
+		case map[string]interface{}:
+			problems = append(problems, checkSettings(file, profilesKey+"."+name+".", p)...)
+		case nil:
+		default:
+			problems = append(problems, configProblem{file, profilesKey + "." + name, "expected a mapping of settings"})
+		}
+	}
+
+	if name, ok := settings["profile"].(string); ok && name != "" {
+		found := false
+		for candidate := range profiles {
+			found = found || strings.EqualFold(candidate, name)
+		}
+		if !found {
+			problems = append(problems, configProblem{file, "profile", fmt.Sprintf("profile %q is not defined under %s", name, profilesKey)})
+		}
+	}
+
+	return problems
+}
+
+// checkSettings checks settings at one level of a config file, prefixing keys in problems
+func checkSettings(file, prefix string, settings map[string]interface{}) []configProblem {
+	var problems []configProblem
+	for _, name := range sortedSettingKeys(settings) {
+		if prefix == "" && name == profilesKey {
+			continue
+		}
+		key, ok := lookupConfigKey(name)
+		if !ok || (prefix != "" && name == "profile") {
+			problems = append(problems, configProblem{file, prefix + name, "unknown key"})
+			continue
+		}
+		if problem := key.check(settings[name]); problem != "" {
+			problems = append(problems, configProblem{file, prefix + name, problem})
+		}
+	}
+	return problems
+}
+
+// sortedSettingKeys returns the keys of settings in order
+func sortedSettingKeys(settings map[string]interface{}) []string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// envSet reports whether a setting is given by its environment variable (viper ignores
+// empty variables)
+func envSet(k configKey) bool {
+	return os.Getenv(k.envName()) != ""
+}
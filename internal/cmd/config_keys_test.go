@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigKeyParse(t *testing.T) {
+	tests := []struct {
+		key      string
+		value    string
+		expected interface{}
+		err      string
+	}{
+		{"api_url", "https://api.staging.versioner.io", "https://api.staging.versioner.io", ""},
+		{"api_url", "api.versioner.io", nil, "expected an http(s) URL"},
+		{"api_key", "0123", "0123", ""},
+		{"fail_on_api_error", "false", false, ""},
+		{"fail_on_api_error", "maybe", nil, "invalid bool value"},
+		{"retry_max_attempts", "6", 6, ""},
+		{"retry_max_attempts", "0", nil, "must be at least 1"},
+		{"retry_jitter", "0.25", 0.25, ""},
+		{"retry_jitter", "1.5", nil, "must be between 0 and 1"},
+		{"retry_deadline", "90s", "90s", ""},
+		{"retry_deadline", "90", nil, "invalid duration value"},
+		{"version_from", "git-tag, package.json", []string{"git-tag", "package.json"}, ""},
+		{"version_from", "setup.py", nil, `unknown version source "setup.py"`},
+		{"output", "xml", nil, "invalid output format"},
+	}
+
+	for _, tt := range tests {
+		key, ok := lookupConfigKey(tt.key)
+		if !ok {
+			t.Fatalf("Unknown key %s", tt.key)
+		}
+		value, err := key.parse(tt.value)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s=%s: expected error containing %q, got %v", tt.key, tt.value, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s=%s: unexpected error: %v", tt.key, tt.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(value, tt.expected) {
+			t.Errorf("%s=%s: expected %#v, got %#v", tt.key, tt.value, tt.expected, value)
+		}
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	settings := map[string]interface{}{
+		"api_key":            "key",
+		"api_url":            "https://api.versioner.io",
+		"retry_max_attempts": "six",
+		"version_from":       []interface{}{"git-tag", "VERSION"},
+		"apikey":             "typo",
+		"profile":            "Staging",
+		"profiles": map[string]interface{}{
+			"staging": map[string]interface{}{"api_url": "https://api.staging.versioner.io"},
+			"broken":  map[string]interface{}{"spool": "yes", "profile": "staging"},
+			"empty":   nil,
+		},
+	}
+
+	problems := validateSettings("config.yaml", settings)
+
+	expected := []configProblem{
+		{"config.yaml", "apikey", "unknown key"},
+		{"config.yaml", "retry_max_attempts", "expected a whole number, got six"},
+		{"config.yaml", "profiles.broken.profile", "unknown key"},
+		{"config.yaml", "profiles.broken.spool", "expected true or false, got yes"},
+	}
+	if !reflect.DeepEqual(problems, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, problems)
+	}
+
+	settings["profile"] = "production"
+	problems = validateSettings("config.yaml", settings)
+	if last := problems[len(problems)-1]; last.Key != "profile" || !strings.Contains(last.Message, "not defined") {
+		t.Errorf("Expected an undefined profile to be reported, got %+v", last)
+	}
+}
+
+func TestErrorProblems(t *testing.T) {
+	err := &multiLineError{"yaml: unmarshal errors:\n  line 2: cannot unmarshal !!seq into string\nproducts.api.paths: required"}
+	problems := errorProblems("f.yaml", err)
+
+	expected := []configProblem{
+		{"f.yaml", "", "line 2: cannot unmarshal !!seq into string"},
+		{"f.yaml", "products.api.paths", "required"},
+	}
+	if !reflect.DeepEqual(problems, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, problems)
+	}
+}
+
+type multiLineError struct{ message string }
+
+func (e *multiLineError) Error() string { return e.message }
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var configProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "Manage named configuration profiles",
+	Long: `Manage named profiles, each a section of the config file with its own settings
+(typically api_url, api_key and ui_url):
+
+  api_key: default-key
+  profile: business-unit-a     # profile used when --profile is not given
+  profiles:
+    business-unit-a:
+      api_key: key-for-a
+    staging:
+      api_url: https://api.staging.versioner.io
+      api_key: staging-key
+
+The profile is selected with --profile, VERSIONER_PROFILE or the profile key. Its
+settings override the top-level settings of the config file; flags and environment
+variables still take precedence.`,
+}
+
+var configProfilesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the profiles in the config file",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigProfilesList,
+}
+
+var configProfilesUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a profile the default",
+	Long:  `Make a profile the default by setting the profile key in the config file.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigProfilesUse,
+}
+
+var configProfilesShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show the settings of a profile (default: the active profile)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runConfigProfilesShow,
+}
+
+func init() {
+	configCmd.AddCommand(configProfilesCmd)
+	configProfilesCmd.AddCommand(configProfilesListCmd)
+	configProfilesCmd.AddCommand(configProfilesUseCmd)
+	configProfilesCmd.AddCommand(configProfilesShowCmd)
+}
+
+// profileSummary is one entry of 'config profiles list'
+type profileSummary struct {
+	Name   string `json:"name"`
+	APIURL string `json:"api_url,omitempty"`
+	UIURL  string `json:"ui_url,omitempty"`
+	Active bool   `json:"active"`
+}
+
+// profileDetail is the structured output of 'config profiles show'
+type profileDetail struct {
+	Name     string            `json:"name"`
+	Active   bool              `json:"active"`
+	Settings map[string]string `json:"settings"`
+}
+
+func runConfigProfilesList(cmd *cobra.Command, args []string) error {
+	_, profiles, err := loadProfiles(configFilePath())
+	if err != nil {
+		return err
+	}
+
+	selected, _, _ := profiles.profile(viper.GetString("profile"))
+	summaries := []profileSummary{}
+	rows := [][]string{}
+	for _, name := range profiles.profileNames() {
+		settings := profiles.Profiles[name]
+		summary := profileSummary{
+			Name:   name,
+			APIURL: profileSetting(settings, "api_url"),
+			UIURL:  profileSetting(settings, "ui_url"),
+			Active: name == selected,
+		}
+		summaries = append(summaries, summary)
+
+		marker := ""
+		if summary.Active {
+			marker = "*"
+		}
+		rows = append(rows, []string{marker, name, orDash(summary.APIURL), orDash(summary.UIURL)})
+	}
+
+	if len(summaries) == 0 && !structuredOutput() {
+		fmt.Fprintf(os.Stderr, "No profiles in %s\n", configFilePath())
+		return nil
+	}
+	return writeList(summaries, []string{"ACTIVE", "NAME", "API URL", "UI URL"}, rows)
+}
+
+func runConfigProfilesUse(cmd *cobra.Command, args []string) error {
+	doc, profiles, err := loadProfiles(writableConfigFile())
+	if err != nil {
+		return err
+	}
+
+	name, _, ok := profiles.profile(args[0])
+	if !ok {
+		return fmt.Errorf("profile %q not found in %s", args[0], doc.Path)
+	}
+	if err := doc.Set("profile", name); err != nil {
+		return err
+	}
+	if err := doc.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Using profile %s by default (%s)\n", name, doc.Path)
+	if env := os.Getenv("VERSIONER_PROFILE"); env != "" && env != name {
+		fmt.Fprintf(os.Stderr, "⚠ VERSIONER_PROFILE=%s overrides the default in this shell\n", env)
+	}
+	return nil
+}
+
+func runConfigProfilesShow(cmd *cobra.Command, args []string) error {
+	_, profiles, err := loadProfiles(configFilePath())
+	if err != nil {
+		return err
+	}
+
+	requested := viper.GetString("profile")
+	if len(args) > 0 {
+		requested = args[0]
+	}
+	if requested == "" {
+		return fmt.Errorf("no active profile (select one with --profile, VERSIONER_PROFILE or 'versioner config profiles use')")
+	}
+
+	name, settings, ok := profiles.profile(requested)
+	if !ok {
+		return fmt.Errorf("profile %q not found in %s", requested, configFilePath())
+	}
+	active, _, _ := profiles.profile(viper.GetString("profile"))
+
+	detail := &profileDetail{Name: name, Active: name == active, Settings: map[string]string{}}
+	for key := range settings {
+		value := profileSetting(settings, key)
+		if key == "api_key" {
+			value = redactSecret(value)
+		}
+		detail.Settings[key] = value
+	}
+
+	if structuredOutput() {
+		return writeResult(detail)
+	}
+
+	fmt.Printf("Profile: %s", detail.Name)
+	if detail.Active {
+		fmt.Printf(" (active)")
+	}
+	fmt.Println()
+
+	keys := make([]string, 0, len(detail.Settings))
+	for key := range detail.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rows := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, []string{key, detail.Settings[key]})
+	}
+	return writeTable(os.Stdout, []string{"KEY", "VALUE"}, rows)
+}
+
+// profileSetting formats a profile setting for display
+func profileSetting(settings map[string]interface{}, key string) string {
+	value, ok := settings[key]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
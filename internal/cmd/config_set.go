@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/terminal"
+	"github.com/versioner-io/versioner-cli/internal/userconfig"
+)
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a value in the config file",
+	Long: `Set a value in the config file, which is created (readable only by you) if it
+doesn't exist. With --profile, the value is set in that profile's section.
+
+Pass - as the value to read it from stdin, which keeps secrets out of your shell
+history and process list.`,
+	Example: `  versioner config set api_url https://api.versioner.io
+  versioner config set retry_max_attempts 6
+  versioner --profile=staging config set api_url https://api.staging.versioner.io
+  echo "$KEY" | versioner config set api_key -`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+func init() {
+	configCmd.AddCommand(configSetCmd)
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, ok := lookupConfigKey(args[0])
+	if !ok {
+		return fmt.Errorf("unknown key %q (see 'versioner config view --all')", args[0])
+	}
+
+	raw := args[1]
+	if raw == "-" {
+		line, err := terminal.ReadSecret(cmd.Context(), os.Stdin)
+		if err != nil {
+			exitPromptCancelled(err)
+			return fmt.Errorf("failed to read value from stdin: %w", err)
+		}
+		raw = strings.TrimSpace(line)
+	} else if key.Secret {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %s passed as an argument is visible in process lists and shell history.\n", key.Name)
+		fmt.Fprintf(os.Stderr, "   Prefer: versioner config set %s -\n\n", key.Name)
+	}
+
+	value, err := key.parse(raw)
+	if err != nil {
+		return err
+	}
+
+	doc, err := userconfig.Load(writableConfigFile())
+	if err != nil {
+		return err
+	}
+
+	path := key.Name
+	profile, _ := cmd.Flags().GetString("profile")
+	if profile != "" && cmd.Flags().Changed("profile") {
+		if key.Name == "profile" {
+			return fmt.Errorf("profile cannot be set inside a profile (use 'versioner config profiles use')")
+		}
+		if strings.Contains(profile, ".") {
+			return fmt.Errorf("invalid profile name %q", profile)
+		}
+		path = profilesKey + "." + profile + "." + key.Name
+	}
+
+	if err := doc.Set(path, value); err != nil {
+		return err
+	}
+
+	// Problems elsewhere in the file don't prevent setting this value
+	var settings map[string]interface{}
+	if err := doc.Decode(&settings); err != nil {
+		return err
+	}
+	for _, problem := range validateSettings(doc.Path, settings) {
+		if problem.Key == path {
+			return fmt.Errorf("%s: %s", problem.Key, problem.Message)
+		}
+	}
+
+	if err := doc.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Set %s in %s\n", path, doc.Path)
+	if envSet(key) {
+		fmt.Fprintf(os.Stderr, "⚠ %s overrides the config file in this shell\n", key.envName())
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/projectconfig"
+	"github.com/versioner-io/versioner-cli/internal/userconfig"
+)
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config files for errors and unknown keys",
+	Long: `Check the config file (including its profiles) and the project's .versioner.yaml,
+if there is one, for unknown keys, values of the wrong type and invalid values.
+
+Exit codes:
+  0 - No problems found
+  1 - Problems found (each is listed on stderr)`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+// configValidation is the structured output of 'config validate'
+type configValidation struct {
+	Valid    bool            `json:"valid"`
+	Files    []string        `json:"files"`
+	Problems []configProblem `json:"problems"`
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	result := &configValidation{Files: []string{}, Problems: []configProblem{}}
+
+	path := configFilePath()
+	if _, err := os.Stat(path); err == nil {
+		result.Files = append(result.Files, path)
+		result.Problems = append(result.Problems, validateConfigFile(path)...)
+	} else if cfgFile != "" {
+		return err
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	projectPath, err := projectconfig.Find(dir)
+	if err != nil {
+		return err
+	}
+	if projectPath != "" {
+		result.Files = append(result.Files, projectPath)
+		result.Problems = append(result.Problems, validateProjectFile(projectPath)...)
+	}
+
+	result.Valid = len(result.Problems) == 0
+
+	if structuredOutput() {
+		if err := writeResult(result); err != nil {
+			return err
+		}
+	} else if len(result.Files) == 0 {
+		fmt.Fprintf(os.Stderr, "No config files found (%s, %s)\n", path, projectconfig.FileName)
+	} else {
+		for _, file := range result.Files {
+			fmt.Fprintf(os.Stderr, "Checked %s\n", file)
+		}
+		for _, problem := range result.Problems {
+			if problem.Key != "" {
+				fmt.Fprintf(os.Stderr, "  ✗ %s: %s: %s\n", problem.File, problem.Key, problem.Message)
+			} else {
+				fmt.Fprintf(os.Stderr, "  ✗ %s: %s\n", problem.File, problem.Message)
+			}
+		}
+	}
+
+	if !result.Valid {
+		return fmt.Errorf("found %d problem(s) in the configuration", len(result.Problems))
+	}
+	if !structuredOutput() && len(result.Files) > 0 {
+		fmt.Fprintf(os.Stderr, "✓ Configuration is valid\n")
+	}
+	return nil
+}
+
+// validateConfigFile checks the user config file at path
+func validateConfigFile(path string) []configProblem {
+	doc, err := userconfig.Load(path)
+	if err != nil {
+		return errorProblems(path, errors.New(strings.TrimPrefix(err.Error(), path+": ")))
+	}
+
+	var settings map[string]interface{}
+	if err := doc.Decode(&settings); err != nil {
+		return errorProblems(path, err)
+	}
+	return validateSettings(path, settings)
+}
+
+// validateProjectFile checks the project config file at path
+func validateProjectFile(path string) []configProblem {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []configProblem{{File: path, Message: err.Error()}}
+	}
+	if _, err = projectconfig.Parse(data); err == nil {
+		return nil
+	}
+	return errorProblems(path, err)
+}
+
+// errorProblems splits an error listing several problems (YAML errors, joined
+// validation errors) into one problem per line
+func errorProblems(path string, err error) []configProblem {
+	var problems []configProblem
+	for _, line := range strings.Split(err.Error(), "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(line, "yaml: unmarshal errors:"))
+		line = strings.TrimPrefix(line, "yaml: ")
+		if line == "" {
+			continue
+		}
+		problem := configProblem{File: path, Message: line}
+		if key, message, ok := strings.Cut(line, ": "); ok && !strings.Contains(key, " ") {
+			problem.Key, problem.Message = key, message
+		}
+		problems = append(problems, problem)
+	}
+	return problems
+}
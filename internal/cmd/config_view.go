@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/userconfig"
+)
+
+// Sources of effective config values, from highest to lowest precedence
+const (
+	sourceFlag    = "flag"
+	sourceEnv     = "env"
+	sourceProfile = "profile"
+	sourceFile    = "file"
+	sourceDefault = "default"
+)
+
+var configViewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Show the effective configuration and where each value comes from",
+	Long: `Show every setting in effect, after combining flags, environment variables, the
+active profile, the config file and defaults, with the source of each value
+(flag, env, profile, file or default). The API key is redacted.`,
+	Example: `  # Why is it posting to the wrong URL?
+  versioner config view
+
+  # Include settings that are not set
+  versioner config view --all`,
+	Args: cobra.NoArgs,
+	RunE: runConfigView,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a setting",
+	Long: `Print the effective value of a setting, as 'config view' shows it. The API key is
+redacted unless --show-secret is given.`,
+	Example: `  versioner config get api_url
+  versioner --profile=staging config get api_url`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigGet,
+}
+
+func init() {
+	configCmd.AddCommand(configViewCmd)
+	configCmd.AddCommand(configGetCmd)
+
+	configViewCmd.Flags().Bool("all", false, "Include settings that are not set")
+	configGetCmd.Flags().Bool("show-secret", false, "Print the API key instead of redacting it")
+}
+
+// configValue is an effective setting in 'config view'
+type configValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source,omitempty"`
+}
+
+// configView is the structured output of 'config view'
+type configView struct {
+	ConfigFile string        `json:"config_file"`
+	Profile    string        `json:"profile,omitempty"`
+	Settings   []configValue `json:"settings"`
+}
+
+func runConfigView(cmd *cobra.Command, args []string) error {
+	settings, err := loadEffectiveConfig()
+	if err != nil {
+		return err
+	}
+	all, _ := cmd.Flags().GetBool("all")
+
+	view := &configView{ConfigFile: configFilePath(), Profile: activeProfile, Settings: []configValue{}}
+	for _, key := range configKeys {
+		value := effectiveValue(key)
+		source := settings.source(key)
+		if value == "" && source == "" && !all {
+			continue
+		}
+		if key.Secret {
+			value = redactSecret(value)
+		}
+		view.Settings = append(view.Settings, configValue{Key: key.Name, Value: value, Source: source})
+	}
+
+	if structuredOutput() {
+		return writeResult(view)
+	}
+
+	location := view.ConfigFile
+	if !settings.exists {
+		location += " (not found)"
+	}
+	fmt.Printf("Config file: %s\n", location)
+	if view.Profile != "" {
+		fmt.Printf("Profile: %s\n", view.Profile)
+	}
+	fmt.Println()
+
+	rows := make([][]string, 0, len(view.Settings))
+	for _, s := range view.Settings {
+		rows = append(rows, []string{s.Key, orDash(s.Value), orDash(s.Source)})
+	}
+	return writeTable(os.Stdout, []string{"KEY", "VALUE", "SOURCE"}, rows)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	key, ok := lookupConfigKey(args[0])
+	if !ok {
+		return fmt.Errorf("unknown key %q (see 'versioner config view --all')", args[0])
+	}
+	if _, err := loadEffectiveConfig(); err != nil {
+		return err
+	}
+
+	value := effectiveValue(key)
+	if value == "" {
+		return fmt.Errorf("%s is not set", key.Name)
+	}
+	if showSecret, _ := cmd.Flags().GetBool("show-secret"); key.Secret && !showSecret {
+		value = redactSecret(value)
+	}
+
+	fmt.Println(value)
+	return nil
+}
+
+// effectiveConfig records which settings the config file and active profile define,
+// to attribute effective values to their source
+type effectiveConfig struct {
+	exists  bool
+	file    map[string]interface{}
+	profile map[string]interface{}
+}
+
+// loadEffectiveConfig applies the selected profile, as other commands do, and reads the
+// config file to attribute values to it
+func loadEffectiveConfig() (*effectiveConfig, error) {
+	if err := applyProfile(); err != nil {
+		return nil, err
+	}
+
+	doc, err := userconfig.Load(configFilePath())
+	if err != nil {
+		return nil, err
+	}
+	settings := &effectiveConfig{file: map[string]interface{}{}}
+	if _, err := os.Stat(doc.Path); err == nil {
+		settings.exists = true
+	}
+	if err := doc.Decode(&settings.file); err != nil {
+		return nil, fmt.Errorf("%s: %w", doc.Path, err)
+	}
+
+	if activeProfile != "" {
+		if profiles, ok := settings.file[profilesKey].(map[string]interface{}); ok {
+			for name, p := range profiles {
+				if strings.EqualFold(name, activeProfile) {
+					settings.profile, _ = p.(map[string]interface{})
+				}
+			}
+		}
+	}
+	return settings, nil
+}
+
+// source returns where the effective value of a setting comes from, or "" if it is not set
+func (c *effectiveConfig) source(key configKey) string {
+	if flag := rootCmd.PersistentFlags().Lookup(key.flagName()); flag != nil && flag.Changed {
+		return sourceFlag
+	}
+	if envSet(key) {
+		return sourceEnv
+	}
+	if _, ok := c.profile[key.Name]; ok {
+		return sourceProfile
+	}
+	if _, ok := c.file[key.Name]; ok {
+		return sourceFile
+	}
+	if effectiveValue(key) != "" {
+		return sourceDefault
+	}
+	return ""
+}
+
+// effectiveValue returns the value of a setting as viper resolves it. The defaults of
+// command flags bound to viper (e.g. track build's --status) are not configuration, and
+// are left out.
+func effectiveValue(key configKey) string {
+	if !viper.IsSet(key.Name) && rootCmd.PersistentFlags().Lookup(key.flagName()) == nil {
+		return ""
+	}
+	if key.Type == settingList {
+		return strings.Join(viper.GetStringSlice(key.Name), ",")
+	}
+	return viper.GetString(key.Name)
+}
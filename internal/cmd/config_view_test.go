@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestConfigSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "api_key: file-key\nretry_max_attempts: 6\nprofiles:\n  staging:\n    api_url: https://api.staging.versioner.io\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfgFile = path
+	viper.Reset()
+	activeProfile = ""
+	t.Cleanup(func() {
+		cfgFile = ""
+		viper.Reset()
+		activeProfile = ""
+	})
+	t.Setenv("VERSIONER_UI_URL", "https://ui.example.com")
+	t.Setenv("VERSIONER_PROFILE", "staging")
+	viper.SetConfigFile(path)
+	viper.SetEnvPrefix("VERSIONER")
+	viper.AutomaticEnv()
+	viper.SetDefault("retry_jitter", 0.1)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := loadEffectiveConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key    string
+		value  string
+		source string
+	}{
+		{"api_url", "https://api.staging.versioner.io", sourceProfile},
+		{"api_key", "file-key", sourceFile},
+		{"ui_url", "https://ui.example.com", sourceEnv},
+		{"profile", "staging", sourceEnv},
+		{"retry_max_attempts", "6", sourceFile},
+		{"retry_jitter", "0.1", sourceDefault},
+		{"product", "", ""},
+	}
+	for _, tt := range tests {
+		key, _ := lookupConfigKey(tt.key)
+		if value := effectiveValue(key); value != tt.value {
+			t.Errorf("%s: expected value %q, got %q", tt.key, tt.value, value)
+		}
+		if source := settings.source(key); source != tt.source {
+			t.Errorf("%s: expected source %q, got %q", tt.key, tt.source, source)
+		}
+	}
+}
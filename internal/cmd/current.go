@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+// exitCodeNotDeployed is the exit code used when there is no deployment to work with
+const exitCodeNotDeployed = 3
+
+var currentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Print the version currently deployed to an environment",
+	Long: `Print the version of a product currently deployed to an environment, i.e. the
+version of its most recent completed deployment.
+
+Only the version is printed, so the output can be used in $(...) substitutions.
+With --output=json|yaml, an object with the version, commit SHA, deployment time
+and deployer is printed instead, and --output=env prints VERSIONER_VERSION,
+VERSIONER_SCM_SHA, VERSIONER_DEPLOYED_AT and VERSIONER_DEPLOYED_BY lines.
+
+Exit codes:
+  0   - Success
+  1   - General error (network, invalid arguments)
+  3   - Nothing has been deployed to the environment
+  4   - API error (validation, authentication)
+  130 - Cancelled (SIGINT/SIGTERM)`,
+	Example: `  # Roll back to whatever is running in production
+  PROD_VERSION=$(versioner current --product=api-service --environment=production)
+
+  # Details as JSON
+  versioner current --product=api-service --environment=production --output=json
+
+  # Export as shell variables
+  eval "$(versioner current --product=api-service --environment=production --output=env)"`,
+	Args: cobra.NoArgs,
+	RunE: runCurrent,
+}
+
+func init() {
+	rootCmd.AddCommand(currentCmd)
+
+	currentCmd.Flags().String("product", "", "Product/application name (required)")
+	currentCmd.Flags().String("environment", "", "Environment name (required)")
+}
+
+// currentDeployment is the structured output of 'current'
+type currentDeployment struct {
+	Product     string     `json:"product"`
+	Environment string     `json:"environment"`
+	Version     string     `json:"version"`
+	SCMSha      string     `json:"scm_sha,omitempty"`
+	DeployedAt  *time.Time `json:"deployed_at,omitempty"`
+	DeployedBy  string     `json:"deployed_by,omitempty"`
+}
+
+func (c *currentDeployment) envVars() []envVar {
+	return []envVar{
+		{"VERSIONER_PRODUCT", c.Product},
+		{"VERSIONER_ENVIRONMENT", c.Environment},
+		{"VERSIONER_VERSION", c.Version},
+		{"VERSIONER_SCM_SHA", c.SCMSha},
+		{"VERSIONER_DEPLOYED_AT", formatTimeRFC3339(c.DeployedAt)},
+		{"VERSIONER_DEPLOYED_BY", c.DeployedBy},
+	}
+}
+
+func runCurrent(cmd *cobra.Command, args []string) error {
+	product, _ := cmd.Flags().GetString("product")
+	environment, _ := cmd.Flags().GetString("environment")
+
+	if product == "" {
+		return fmt.Errorf("product is required (use --product)")
+	}
+	if environment == "" {
+		return fmt.Errorf("environment is required (use --environment)")
+	}
+
+	client, err := newAPIClient(cmd)
+	if err != nil {
+		return err
+	}
+	client.FailOnAPIError = true
+	client.Spool = nil
+
+	deployment, err := findCurrentDeployment(cmd.Context(), client, product, environment)
+	if err != nil {
+		exitQueryError(err)
+	}
+	if deployment == nil {
+		exitNotDeployed(fmt.Sprintf("Nothing has been deployed: %s → %s", product, environment))
+	}
+
+	if structuredOutput() {
+		return writeResult(&currentDeployment{
+			Product:     product,
+			Environment: environment,
+			Version:     deployment.Version,
+			SCMSha:      deployment.SCMSha,
+			DeployedAt:  deploymentTime(deployment),
+			DeployedBy:  deployment.DeployedBy,
+		})
+	}
+
+	fmt.Println(deployment.Version)
+	return nil
+}
+
+// exitNotDeployed reports that there is no deployment to work with, including the
+// structured result for --output, and exits with exitCodeNotDeployed
+func exitNotDeployed(message string) {
+	fmt.Fprintf(os.Stderr, "%s\n", message)
+	if structuredOutput() {
+		_ = writeResult(&eventResult{Error: &errorResult{Type: "not_deployed", Message: message}})
+	}
+	os.Exit(exitCodeNotDeployed)
+}
+
+// findCurrentDeployment returns the most recent completed deployment of product to
+// environment, or nil if there is none
+func findCurrentDeployment(ctx context.Context, client *api.Client, product, environment string) (*api.Deployment, error) {
+	deployments, err := client.ListDeployments(ctx, api.DeploymentFilter{
+		ProductName:     product,
+		EnvironmentName: environment,
+		Status:          status.Completed,
+		Limit:           1,
+	})
+	if err != nil || len(deployments) == 0 {
+		return nil, err
+	}
+	return &deployments[0], nil
+}
+
+// deploymentTime returns when a deployment took effect
+func deploymentTime(d *api.Deployment) *time.Time {
+	if d.DeployedAt != nil {
+		return d.DeployedAt
+	}
+	if d.CompletedAt != nil {
+		return d.CompletedAt
+	}
+	return d.StartedAt
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/versioner-io/versioner-cli/internal/api"
+)
+
+func TestFindCurrentDeployment(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantVersion string
+		wantNil     bool
+	}{
+		{"deployed", `{"items": [{"version": "1.2.3", "scm_sha": "abc"}], "total": 7, "page": 1, "page_size": 100}`, "1.2.3", false},
+		{"nothing deployed", `{"items": [], "total": 0, "page": 1, "page_size": 100}`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query map[string][]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.Query()
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test-key", false, true)
+			deployment, err := findCurrentDeployment(context.Background(), client, "api-service", "production")
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if got := query["status"]; len(got) != 1 || got[0] != "completed" {
+				t.Errorf("Expected status=completed filter, got %v", got)
+			}
+			if tt.wantNil {
+				if deployment != nil {
+					t.Errorf("Expected no deployment, got %+v", deployment)
+				}
+				return
+			}
+			if deployment == nil || deployment.Version != tt.wantVersion {
+				t.Errorf("Expected version %s, got %+v", tt.wantVersion, deployment)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/report"
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+// finalEventTimeout bounds how long exec waits to record the outcome of the wrapped command
+const finalEventTimeout = 2 * time.Minute
+
+var execCmd = &cobra.Command{
+	Use:   "exec [flags] -- <command> [args...]",
+	Short: "Run a deployment command and track its lifecycle",
+	Long: `Run a deployment command and record its lifecycle with the Versioner API.
+
+A 'started' deployment event is sent before the command runs, which triggers
+preflight checks. If the checks pass, the command is run with stdin, stdout and
+stderr passed through. When it exits, a 'completed' event is sent for exit code
+0, 'failed' for any other exit code, or 'aborted' if the command was stopped by
+a signal.
+
+Exit codes:
+  <n> - Exit code of the wrapped command
+  130 - The CLI was interrupted (SIGINT/SIGTERM) and the command exited 0
+  1   - General error (network, invalid arguments) before the command ran
+  4   - API error (validation, authentication) before the command ran
+  5   - Preflight check failure (deployment blocked, command not run)`,
+	Example: `  # Deploy and track the whole lifecycle
+  versioner exec \
+    --product=api-service \
+    --environment=production \
+    --version=1.2.3 \
+    -- kubectl apply -f deployment.yaml`,
+	Args:    cobra.MinimumNArgs(1),
+	PreRunE: loadProjectConfig,
+	RunE:    runExec,
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	addDeploymentEventFlags(execCmd)
+
+	// Everything after the first positional argument belongs to the wrapped command
+	execCmd.Flags().SetInterspersed(false)
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	// Auto-detect CI/CD environment
+	detected := cicd.Detect()
+
+	event, err := buildDeploymentEvent(cmd, detected, status.Started)
+	if err != nil {
+		return err
+	}
+	applySubmitFlags(cmd, detected, event)
+
+	// A supplied idempotency key covers the whole run, so make it unique per event
+	suppliedKey, _ := cmd.Flags().GetString("idempotency-key")
+	if suppliedKey != "" {
+		event.IdempotencyKey = suppliedKey + "-" + status.Started
+	}
+
+	// Create API client
+	client, err := newAPIClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	wait, err := deploymentWaitOptions(cmd, event)
+	if err != nil {
+		return err
+	}
+
+	if verbose {
+		printDeploymentEvent(event, detected, client.BaseURL)
+	}
+
+	// Send the started event (exits with code 5 if preflight checks fail)
+	started := submitDeploymentEvent(cmd, client, event, wait)
+	handlePreflightWarnings(started.Warnings)
+	fmt.Fprintf(os.Stderr, "✓ Deployment started (Event ID: %s)\n", started.ID)
+	fmt.Fprintf(os.Stderr, "→ Running: %v\n\n", args)
+
+	// With --output, stdout carries only the result document
+	childStdout := io.Writer(os.Stdout)
+	if structuredOutput() {
+		childStdout = os.Stderr
+	}
+	exitCode, interrupted, runErr := runChild(args, childStdout)
+
+	// Determine the final deployment status from how the command ended
+	finalStatus, exitCode := execOutcome(exitCode, interrupted)
+	if runErr != nil {
+		fmt.Fprintf(os.Stderr, "\nError running command: %s\n", runErr.Error())
+	}
+
+	// The final event runs no preflight checks, but the result reports the started event's
+	skippedPreflight := event.SkipPreflightChecks
+	completedAt := time.Now().UTC()
+	event.Status = finalStatus
+	event.CompletedAt = &completedAt
+	event.SkipPreflightChecks = false
+	event.IdempotencyKey = deploymentIdempotencyKey(cmd, detected, event)
+	if suppliedKey != "" {
+		event.IdempotencyKey = suppliedKey + "-" + finalStatus
+	}
+
+	// Record the outcome even if the CLI itself was interrupted, but don't hang forever
+	finalCtx, cancel := context.WithTimeout(context.WithoutCancel(cmd.Context()), finalEventTimeout)
+	defer cancel()
+
+	resp, err := client.CreateDeploymentEventWithContext(finalCtx, event)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n")
+		trackExitCode := reportDeploymentError(err)
+		result := newExecResult(event, nil, started, skippedPreflight, exitCode)
+		result.Error = newErrorResult(err)
+		_ = reportResult(result)
+		if exitCode == 0 {
+			exitCode = trackExitCode
+		}
+		os.Exit(exitCode)
+	}
+
+	fmt.Fprintf(os.Stderr, "\n✓ Deployment %s (Event ID: %s)\n", finalStatus, resp.ID)
+	mirrorGitHubDeployment(finalCtx, cmd, event, resp)
+
+	ciReporter().Success(report.Success{
+		Action:      "Deployment",
+		Environment: event.EnvironmentName,
+		Status:      finalStatus,
+		Version:     event.Version,
+		SCMSha:      event.SCMSha,
+		UIURL:       viper.GetString("ui_url"),
+		ResourceID:  resp.ID,
+	})
+
+	if err := reportResult(newExecResult(event, resp, started, skippedPreflight, exitCode)); err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+	return nil
+}
+
+// execOutcome returns the final deployment status for how the wrapped command ended, and
+// the exit code to propagate. A command may handle SIGINT and exit 0, but the run was
+// still aborted, so the CLI exits 130 rather than report success.
+func execOutcome(exitCode int, interrupted bool) (string, int) {
+	switch {
+	case interrupted:
+		if exitCode == 0 {
+			exitCode = exitCodeCancelled
+		}
+		return status.Aborted, exitCode
+	case exitCode != 0:
+		return status.Failed, exitCode
+	}
+	return status.Completed, 0
+}
+
+// newExecResult describes the outcome of a wrapped deployment: the final event and its
+// response, the preflight outcome of the started event (which skippedPreflight says
+// whether it skipped), and the command's exit code
+func newExecResult(event *api.DeploymentEventCreate, resp, started *api.DeploymentResponse, skippedPreflight bool, exitCode int) *eventResult {
+	result := &eventResult{Event: event, ExitCode: &exitCode}
+	if resp != nil {
+		result.Response = resp
+	}
+	if started.Status != api.StatusQueued && started.Status != api.StatusNotRecorded {
+		result.PreflightResult = preflightPassed
+		if skippedPreflight {
+			result.PreflightResult = preflightSkipped
+		}
+	}
+	return result
+}
+
+// runChild runs a command with stdio passthrough, forwarding SIGINT and SIGTERM to it.
+// It returns the exit code to propagate, whether the command was stopped by a signal,
+// and any error that prevented the command from starting.
+func runChild(args []string, stdout io.Writer) (exitCode int, interrupted bool, err error) {
+	child := exec.Command(args[0], args[1:]...)
+	child.Stdin = os.Stdin
+	child.Stdout = stdout
+	child.Stderr = os.Stderr
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	if err := child.Start(); err != nil {
+		// Match the shell convention for commands that cannot be executed
+		if errors.Is(err, exec.ErrNotFound) {
+			return 127, false, err
+		}
+		return 126, false, err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- child.Wait()
+	}()
+
+	for {
+		select {
+		case sig := <-signals:
+			interrupted = true
+			_ = child.Process.Signal(sig)
+
+		case waitErr := <-done:
+			var exitErr *exec.ExitError
+			if waitErr != nil && !errors.As(waitErr, &exitErr) {
+				return 1, interrupted, waitErr
+			}
+
+			if ws, ok := child.ProcessState.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+				return 128 + int(ws.Signal()), true, nil
+			}
+			return child.ProcessState.ExitCode(), interrupted, nil
+		}
+	}
+}
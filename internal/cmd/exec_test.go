@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+func TestRunChild(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	tests := []struct {
+		name            string
+		args            []string
+		wantExitCode    int
+		wantInterrupted bool
+		wantErr         bool
+	}{
+		{"success", []string{"sh", "-c", "exit 0"}, 0, false, false},
+		{"failure exit code is propagated", []string{"sh", "-c", "exit 3"}, 3, false, false},
+		{"killed by signal", []string{"sh", "-c", "kill -TERM $$"}, 143, true, false},
+		{"command not found", []string{"versioner-test-no-such-command"}, 127, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode, interrupted, err := runChild(tt.args, os.Stdout)
+
+			if exitCode != tt.wantExitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.wantExitCode, exitCode)
+			}
+			if interrupted != tt.wantInterrupted {
+				t.Errorf("Expected interrupted=%v, got %v", tt.wantInterrupted, interrupted)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExecOutcome(t *testing.T) {
+	tests := []struct {
+		name         string
+		exitCode     int
+		interrupted  bool
+		wantStatus   string
+		wantExitCode int
+	}{
+		{"success", 0, false, status.Completed, 0},
+		{"failure", 3, false, status.Failed, 3},
+		{"killed by signal", 130, true, status.Aborted, 130},
+		{"interrupted but exited cleanly", 0, true, status.Aborted, exitCodeCancelled},
+		{"interrupted with failure", 2, true, status.Aborted, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finalStatus, exitCode := execOutcome(tt.exitCode, tt.interrupted)
+			if finalStatus != tt.wantStatus {
+				t.Errorf("Expected status %s, got %s", tt.wantStatus, finalStatus)
+			}
+			if exitCode != tt.wantExitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.wantExitCode, exitCode)
+			}
+		})
+	}
+}
+
+func TestRunExec_SkipPreflightChecks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX commands")
+	}
+
+	var skipped []bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event api.DeploymentEventCreate
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		skipped = append(skipped, event.SkipPreflightChecks)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "evt-1", "status": "` + event.Status + `"}`))
+	}))
+	defer server.Close()
+
+	t.Cleanup(viper.Reset)
+	viper.Set("api_url", server.URL)
+	viper.Set("api_key", "test-key")
+	viper.Set("ui_url", "https://app.versioner.io")
+	retry := api.DefaultRetryPolicy()
+	viper.Set("retry_max_attempts", 1)
+	viper.Set("retry_base_backoff", retry.BaseBackoff)
+	viper.Set("retry_max_backoff", retry.MaxBackoff)
+	viper.Set("retry_deadline", retry.Deadline)
+	outputFormat = outputJSON
+	t.Cleanup(func() { outputFormat = outputText })
+
+	c := &cobra.Command{Use: "exec"}
+	addDeploymentEventFlags(c)
+	for flag, value := range map[string]string{"product": "api", "environment": "production", "version": "1.2.3", "skip-preflight-checks": "true"} {
+		if err := c.Flags().Set(flag, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.SetContext(context.Background())
+
+	// The result document goes to stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	runErr := runExec(c, []string{"true"})
+	os.Stdout = stdout
+	_ = w.Close()
+	if runErr != nil {
+		t.Fatalf("Expected no error, got: %v", runErr)
+	}
+
+	var result struct {
+		PreflightResult string `json:"preflight_result"`
+	}
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	if result.PreflightResult != preflightSkipped {
+		t.Errorf("Expected preflight_result %q, got %q", preflightSkipped, result.PreflightResult)
+	}
+	if len(skipped) != 2 || !skipped[0] || skipped[1] {
+		t.Errorf("Expected only the started event to skip preflight checks, got %v", skipped)
+	}
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/spool"
+)
+
+var flushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Send queued events that could not be delivered earlier",
+	Long: `Send events that were queued in the local spool because the Versioner API
+could not be reached (or returned an error) while --fail-on-api-error=false.
+
+Events are replayed in the order they were queued, with their original
+timestamps and idempotency keys, so replaying an event the API already recorded
+does not create a duplicate. Deployments are replayed without preflight checks,
+as they have already happened. Replay stops at the first event that still cannot
+be delivered, leaving it and everything after it queued - including events the
+API refuses for now (authentication errors, rate limiting). Events the API
+rejects as invalid (HTTP 400, 404 or 422) or blocks by preflight checks (HTTP
+409, 423 or 428) are moved to the spool's rejected/ directory.
+
+Exit codes:
+  0 - All queued events were delivered
+  1 - Some events remain queued (API still unreachable or refusing them)
+  4 - Some events were rejected by the API`,
+	Example: `  # Replay queued events from the default spool directory
+  versioner flush
+
+  # Replay events from a shared spool directory on a CI runner
+  versioner flush --spool-dir=/var/lib/versioner/spool`,
+	Args: cobra.NoArgs,
+	RunE: runFlush,
+}
+
+func init() {
+	rootCmd.AddCommand(flushCmd)
+}
+
+// flushResult describes what happened to one queued event
+type flushResult int
+
+const (
+	flushDelivered flushResult = iota
+	flushRejected
+	flushQueued
+)
+
+func (r flushResult) String() string {
+	switch r {
+	case flushDelivered:
+		return "delivered"
+	case flushRejected:
+		return "rejected"
+	default:
+		return "queued"
+	}
+}
+
+// flushSummary is the structured output of 'flush'
+type flushSummary struct {
+	SpoolDir  string         `json:"spool_dir"`
+	Delivered int            `json:"delivered"`
+	Rejected  int            `json:"rejected"`
+	Queued    int            `json:"queued"`
+	Events    []flushedEvent `json:"events"`
+}
+
+// flushedEvent describes what happened to one queued event in structured output
+type flushedEvent struct {
+	Kind           string `json:"kind"`
+	Description    string `json:"description"`
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	Result         string `json:"result"`
+	Detail         string `json:"detail,omitempty"`
+}
+
+func (s *flushSummary) envVars() []envVar {
+	return []envVar{
+		{"VERSIONER_DELIVERED", strconv.Itoa(s.Delivered)},
+		{"VERSIONER_REJECTED", strconv.Itoa(s.Rejected)},
+		{"VERSIONER_QUEUED", strconv.Itoa(s.Queued)},
+	}
+}
+
+func runFlush(cmd *cobra.Command, args []string) error {
+	client, err := newAPIClient(cmd)
+	if err != nil {
+		return err
+	}
+	// Replays must report every failure rather than queueing the event again
+	client.FailOnAPIError = true
+	client.Spool = nil
+
+	s, err := openSpool()
+	if err != nil {
+		return err
+	}
+
+	// Hold the lock for the whole replay so concurrent flushes never send an event twice
+	unlock, err := s.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	summary := &flushSummary{SpoolDir: s.Dir, Events: []flushedEvent{}}
+
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "No queued events in %s\n", s.Dir)
+		if structuredOutput() {
+			return writeResult(summary)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Flushing %d queued event(s) from %s\n\n", len(entries), s.Dir)
+
+	for i, entry := range entries {
+		// Keep the original order: once one event can't be delivered, leave the rest queued
+		if summary.Queued > 0 {
+			for _, remaining := range entries[i:] {
+				summary.Events = append(summary.Events, newFlushedEvent(remaining, flushQueued, ""))
+			}
+			summary.Queued += len(entries) - i
+			break
+		}
+
+		result, detail := replayEntry(cmd.Context(), client, entry)
+		switch result {
+		case flushDelivered:
+			if err := s.Remove(entry); err != nil {
+				return fmt.Errorf("event delivered but could not be removed from the spool: %w", err)
+			}
+			summary.Delivered++
+			fmt.Fprintf(os.Stderr, "  ✓ %s - delivered (%s)\n", describeEntry(entry), detail)
+
+		case flushRejected:
+			path, err := s.Reject(entry, detail)
+			if err != nil {
+				return err
+			}
+			summary.Rejected++
+			fmt.Fprintf(os.Stderr, "  ✗ %s - rejected: %s\n", describeEntry(entry), detail)
+			fmt.Fprintf(os.Stderr, "    Moved to %s\n", path)
+
+		case flushQueued:
+			summary.Queued++
+			fmt.Fprintf(os.Stderr, "  … %s - still queued: %s\n", describeEntry(entry), detail)
+		}
+		summary.Events = append(summary.Events, newFlushedEvent(entry, result, detail))
+	}
+
+	fmt.Fprintf(os.Stderr, "\n%d delivered, %d rejected, %d still queued\n", summary.Delivered, summary.Rejected, summary.Queued)
+
+	if structuredOutput() {
+		if err := writeResult(summary); err != nil {
+			return err
+		}
+	}
+
+	if summary.Queued > 0 {
+		os.Exit(1)
+	}
+	if summary.Rejected > 0 {
+		os.Exit(4)
+	}
+	return nil
+}
+
+// newFlushedEvent describes the outcome of replaying entry
+func newFlushedEvent(entry *spool.Entry, result flushResult, detail string) flushedEvent {
+	return flushedEvent{
+		Kind:           entry.Kind,
+		Description:    describeEntry(entry),
+		IdempotencyKey: entry.IdempotencyKey,
+		Result:         result.String(),
+		Detail:         detail,
+	}
+}
+
+// replayEntry sends one queued event and classifies the outcome
+func replayEntry(ctx context.Context, client *api.Client, entry *spool.Entry) (flushResult, string) {
+	var err error
+	var eventID string
+
+	switch entry.Kind {
+	case "build":
+		var event api.BuildEventCreate
+		if err := json.Unmarshal(entry.Payload, &event); err != nil {
+			return flushRejected, fmt.Sprintf("invalid payload: %s", err.Error())
+		}
+		event.IdempotencyKey = entry.IdempotencyKey
+
+		var resp *api.BuildResponse
+		if resp, err = client.CreateBuildEventWithContext(ctx, &event); err == nil {
+			eventID = resp.ID
+		}
+
+	case "deployment":
+		var event api.DeploymentEventCreate
+		if err := json.Unmarshal(entry.Payload, &event); err != nil {
+			return flushRejected, fmt.Sprintf("invalid payload: %s", err.Error())
+		}
+		event.IdempotencyKey = entry.IdempotencyKey
+		// The deployment has already happened; replaying only backfills the audit trail,
+		// so a later deployment in progress or a schedule must not block it
+		event.SkipPreflightChecks = true
+
+		var resp *api.DeploymentResponse
+		if resp, err = client.CreateDeploymentEventWithContext(ctx, &event); err == nil {
+			eventID = resp.ID
+		}
+
+	default:
+		return flushRejected, fmt.Sprintf("unknown event kind %q", entry.Kind)
+	}
+
+	if err == nil {
+		return flushDelivered, "Event ID: " + eventID
+	}
+
+	// Invalid events are rejected for good, as are preflight blocks, which would otherwise
+	// hold up the rest of the queue forever. Auth errors (e.g. a wrong or expired key) and
+	// rate limiting may clear up, so the event stays queued.
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		detail := fmt.Sprintf("HTTP %d: %s", apiErr.StatusCode, apiErr.Error())
+		if apiErr.IsPermanent() || apiErr.IsPreflightError() {
+			return flushRejected, detail
+		}
+		return flushQueued, detail
+	}
+
+	return flushQueued, err.Error()
+}
+
+// describeEntry returns a one-line description of a queued event
+func describeEntry(entry *spool.Entry) string {
+	var summary struct {
+		ProductName     string `json:"product_name"`
+		Version         string `json:"version"`
+		EnvironmentName string `json:"environment_name"`
+		Status          string `json:"status"`
+	}
+	_ = json.Unmarshal(entry.Payload, &summary)
+
+	target := summary.ProductName + " " + summary.Version
+	if summary.EnvironmentName != "" {
+		target += " → " + summary.EnvironmentName
+	}
+
+	return fmt.Sprintf("%s %s (%s, queued %s)", entry.Kind, target, summary.Status, entry.QueuedAt.Local().Format("2006-01-02 15:04:05"))
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/spool"
+)
+
+func TestReplayEntry(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		expected   flushResult
+	}{
+		{"delivered", http.StatusCreated, flushDelivered},
+		{"invalid event", http.StatusUnprocessableEntity, flushRejected},
+		{"bad request", http.StatusBadRequest, flushRejected},
+		{"not found", http.StatusNotFound, flushRejected},
+		{"wrong API key", http.StatusUnauthorized, flushQueued},
+		{"forbidden", http.StatusForbidden, flushQueued},
+		{"concurrent deployment", http.StatusConflict, flushRejected},
+		{"blocked by schedule", http.StatusLocked, flushRejected},
+		{"precondition required", http.StatusPreconditionRequired, flushRejected},
+		{"rate limited", http.StatusTooManyRequests, flushQueued},
+		{"server error", http.StatusInternalServerError, flushQueued},
+	}
+
+	payload, err := json.Marshal(&api.DeploymentEventCreate{ProductName: "api", Version: "1.2.3", EnvironmentName: "production", Status: "completed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := &spool.Entry{Kind: "deployment", IdempotencyKey: "vk1-test", Payload: payload, QueuedAt: time.Now()}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				if tt.statusCode == http.StatusCreated {
+					_, _ = w.Write([]byte(`{"id": "evt-1"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"detail": "nope"}`))
+			}))
+			defer server.Close()
+
+			client := api.NewClient(server.URL, "test-key", false, true)
+			client.Retry.MaxAttempts = 1
+
+			if result, detail := replayEntry(context.Background(), client, entry); result != tt.expected {
+				t.Errorf("Expected %s, got %s (%s)", tt.expected, result, detail)
+			}
+		})
+	}
+}
+
+func TestRunFlush_SkipsPreflightChecks(t *testing.T) {
+	// The API blocks deployments with a preflight conflict unless checks are skipped
+	var delivered []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event struct {
+			Status              string `json:"status"`
+			SkipPreflightChecks bool   `json:"skip_preflight_checks"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/deployment-events/" && !event.SkipPreflightChecks {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"detail": {"error": "Deployment Conflict", "code": "DEPLOYMENT_IN_PROGRESS", "message": "Another deployment is in progress"}}`))
+			return
+		}
+		delivered = append(delivered, r.URL.Path+" "+event.Status)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "evt-1", "version_id": "ver-1"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	s := spool.New(dir)
+	queued := []struct {
+		kind  string
+		event interface{}
+	}{
+		{"deployment", &api.DeploymentEventCreate{ProductName: "api", Version: "1.2.3", EnvironmentName: "production", Status: "started"}},
+		{"deployment", &api.DeploymentEventCreate{ProductName: "api", Version: "1.2.3", EnvironmentName: "production", Status: "completed"}},
+		{"build", &api.BuildEventCreate{ProductName: "api", Version: "1.2.4", Status: "completed"}},
+	}
+	for i, q := range queued {
+		if _, err := s.Enqueue(q.kind, fmt.Sprintf("vk1-test-%d", i), q.event, errors.New("unreachable")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Cleanup(viper.Reset)
+	viper.Set("api_url", server.URL)
+	viper.Set("api_key", "test-key")
+	viper.Set("spool_dir", dir)
+	retry := api.DefaultRetryPolicy()
+	viper.Set("retry_max_attempts", 1)
+	viper.Set("retry_base_backoff", retry.BaseBackoff)
+	viper.Set("retry_max_backoff", retry.MaxBackoff)
+	viper.Set("retry_deadline", retry.Deadline)
+
+	c := &cobra.Command{Use: "flush"}
+	c.SetContext(context.Background())
+	if err := runFlush(c, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(delivered) != len(queued) {
+		t.Errorf("Expected every event to be delivered, got %v", delivered)
+	}
+	if entries, err := s.List(); err != nil || len(entries) != 0 {
+		t.Errorf("Expected an empty spool, got %d entries (%v)", len(entries), err)
+	}
+}
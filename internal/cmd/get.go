@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/api"
+)
+
+var getCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Query deployments, versions and environments",
+	Long: `Query the Versioner API for recorded deployments, product versions and the
+version currently deployed to each environment.
+Use 'get deployments' to list deployments.
+Use 'get versions' to list the versions of a product.
+Use 'get environments' to show the current version per environment.
+
+Results are printed as a table by default, or as JSON/YAML with --output=json|yaml.
+
+Exit codes:
+  0   - Success
+  1   - General error (network, invalid arguments)
+  4   - API error (validation, authentication)
+  130 - Cancelled (SIGINT/SIGTERM)`,
+}
+
+func init() {
+	rootCmd.AddCommand(getCmd)
+}
+
+// newQueryClient creates an API client for read-only commands
+func newQueryClient(cmd *cobra.Command) (*api.Client, error) {
+	if outputFormat == outputEnv {
+		return nil, fmt.Errorf("--output=%s is not supported by '%s'", outputEnv, cmd.CommandPath())
+	}
+
+	client, err := newAPIClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+	// There is nothing to queue, and an empty result must not hide an API error
+	client.FailOnAPIError = true
+	client.Spool = nil
+
+	return client, nil
+}
+
+// writeList writes a list result as a table, or as a JSON/YAML document with --output
+func writeList(value interface{}, headers []string, rows [][]string) error {
+	if structuredOutput() {
+		return writeResult(value)
+	}
+	return writeTable(os.Stdout, headers, rows)
+}
+
+// exitQueryError reports a failed query, including the structured result for --output,
+// and exits with the documented exit code
+func exitQueryError(err error) {
+	code := reportQueryError(err)
+	if structuredOutput() {
+		_ = writeResult(&eventResult{Error: newErrorResult(err)})
+	}
+	os.Exit(code)
+}
+
+// reportQueryError displays a failed query and returns the exit code for it
+func reportQueryError(err error) int {
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "Cancelled: %s\n", err.Error())
+		return exitCodeCancelled
+	}
+	if apiErr, ok := err.(*api.APIError); ok {
+		fmt.Fprintf(os.Stderr, "API error: %s\n", apiErr.Error())
+		return 4
+	}
+	fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+	return 1
+}
+
+// parseTimeFlag parses a time filter given either as an RFC 3339 timestamp or as a
+// duration before now (e.g. "24h")
+func parseTimeFlag(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use RFC 3339, e.g. 2025-01-02T15:04:05Z, or a duration such as 24h)", value)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+var getDeploymentsCmd = &cobra.Command{
+	Use:   "deployments",
+	Short: "List recorded deployments",
+	Long: `List recorded deployments, most recent first.
+
+Filter by product, environment, status and time range. --since and --until take
+an RFC 3339 timestamp or a duration before now (e.g. 24h).`,
+	Example: `  # Deployments to production in the last day
+  versioner get deployments --product=api-service --environment=production --since=24h
+
+  # Failed deployments as JSON
+  versioner get deployments --status=failed --output=json`,
+	Args: cobra.NoArgs,
+	RunE: runGetDeployments,
+}
+
+func init() {
+	getCmd.AddCommand(getDeploymentsCmd)
+
+	getDeploymentsCmd.Flags().String("product", "", "Only deployments of this product")
+	getDeploymentsCmd.Flags().String("environment", "", "Only deployments to this environment")
+	getDeploymentsCmd.Flags().String("status", "", "Only deployments with this status (pending, started, completed, failed, aborted)")
+	getDeploymentsCmd.Flags().String("since", "", "Only deployments at or after this time (RFC 3339 or duration, e.g. 24h)")
+	getDeploymentsCmd.Flags().String("until", "", "Only deployments before this time (RFC 3339 or duration, e.g. 1h)")
+	getDeploymentsCmd.Flags().Int("limit", 50, "Maximum number of deployments to list (0 = all)")
+}
+
+func runGetDeployments(cmd *cobra.Command, args []string) error {
+	filter := api.DeploymentFilter{}
+	filter.ProductName, _ = cmd.Flags().GetString("product")
+	filter.EnvironmentName, _ = cmd.Flags().GetString("environment")
+	filter.Limit, _ = cmd.Flags().GetInt("limit")
+
+	if statusValue, _ := cmd.Flags().GetString("status"); statusValue != "" {
+		if !status.IsValid(statusValue) {
+			return fmt.Errorf("invalid --status %q (use pending, started, completed, failed or aborted)", statusValue)
+		}
+		filter.Status = status.GetCanonical(statusValue)
+	}
+
+	now := time.Now()
+	var err error
+	since, _ := cmd.Flags().GetString("since")
+	if filter.Since, err = parseTimeFlag(since, now); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	until, _ := cmd.Flags().GetString("until")
+	if filter.Until, err = parseTimeFlag(until, now); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+	if filter.Limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
+	client, err := newQueryClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	deployments, err := client.ListDeployments(cmd.Context(), filter)
+	if err != nil {
+		exitQueryError(err)
+	}
+	if deployments == nil {
+		deployments = []api.Deployment{}
+	}
+
+	rows := make([][]string, 0, len(deployments))
+	for _, d := range deployments {
+		rows = append(rows, []string{d.ProductName, d.EnvironmentName, d.Version, d.Status, formatTime(deploymentTime(&d)), orDash(d.DeployedBy)})
+	}
+
+	return writeList(deployments, []string{"PRODUCT", "ENVIRONMENT", "VERSION", "STATUS", "DEPLOYED AT", "DEPLOYED BY"}, rows)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/api"
+)
+
+var getEnvironmentsCmd = &cobra.Command{
+	Use:   "environments",
+	Short: "Show the version currently deployed to each environment",
+	Long: `Show the version of each product currently deployed to each environment.
+
+Without --product, every product is listed.`,
+	Example: `  # What is deployed where?
+  versioner get environments --product=api-service
+
+  # Use in a pipeline
+  versioner get environments --product=api-service --output=json | jq -r '.[] | select(.environment_name=="staging") | .version'`,
+	Args: cobra.NoArgs,
+	RunE: runGetEnvironments,
+}
+
+func init() {
+	getCmd.AddCommand(getEnvironmentsCmd)
+
+	getEnvironmentsCmd.Flags().String("product", "", "Only this product")
+}
+
+func runGetEnvironments(cmd *cobra.Command, args []string) error {
+	product, _ := cmd.Flags().GetString("product")
+
+	client, err := newQueryClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	environments, err := client.ListEnvironments(cmd.Context(), product)
+	if err != nil {
+		exitQueryError(err)
+	}
+	if environments == nil {
+		environments = []api.EnvironmentVersion{}
+	}
+
+	rows := make([][]string, 0, len(environments))
+	for _, e := range environments {
+		rows = append(rows, []string{e.EnvironmentName, e.ProductName, orDash(e.Version), orDash(e.Status), formatTime(e.DeployedAt), orDash(e.DeployedBy)})
+	}
+
+	return writeList(environments, []string{"ENVIRONMENT", "PRODUCT", "VERSION", "STATUS", "DEPLOYED AT", "DEPLOYED BY"}, rows)
+}
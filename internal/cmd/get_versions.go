@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/api"
+)
+
+var getVersionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "List the versions of a product",
+	Long:  `List the versions recorded for a product, most recent first.`,
+	Example: `  # Latest versions of a product
+  versioner get versions --product=api-service
+
+  # All versions as YAML
+  versioner get versions --product=api-service --limit=0 --output=yaml`,
+	Args: cobra.NoArgs,
+	RunE: runGetVersions,
+}
+
+func init() {
+	getCmd.AddCommand(getVersionsCmd)
+
+	getVersionsCmd.Flags().String("product", "", "Product/application name (required)")
+	getVersionsCmd.Flags().Int("limit", 50, "Maximum number of versions to list (0 = all)")
+}
+
+func runGetVersions(cmd *cobra.Command, args []string) error {
+	product, _ := cmd.Flags().GetString("product")
+	if product == "" {
+		return fmt.Errorf("product is required (use --product)")
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
+	client, err := newQueryClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	versions, err := client.ListVersions(cmd.Context(), product, limit)
+	if err != nil {
+		exitQueryError(err)
+	}
+	if versions == nil {
+		versions = []api.Version{}
+	}
+
+	rows := make([][]string, 0, len(versions))
+	for _, v := range versions {
+		rows = append(rows, []string{v.Version, orDash(v.BuildNumber), orDash(shortSHA(v.SCMSha)), orDash(v.Status), formatTime(v.CreatedAt)})
+	}
+
+	return writeList(versions, []string{"VERSION", "BUILD", "COMMIT", "STATUS", "CREATED AT"}, rows)
+}
+
+// shortSHA abbreviates a commit SHA for table output
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+)
+
+var (
+	invocationNonceOnce sync.Once
+	invocationNonce     string
+)
+
+// deriveIdempotencyKey returns a stable idempotency key for one logical event.
+//
+// The key is a hash of the event kind, product, version, environment, status, CI
+// invocation ID and retry ID (the run attempt counter, or the job ID on systems that
+// give a retried job a new ID), so every retry of the same submission - including a
+// re-run of this CLI within the same CI job - sends the same key, while a re-run of
+// the CI job records a new event. Without an invocation ID there is nothing to tie
+// separate runs together, so a random per-process value is used instead and only
+// retries within this process share the key.
+//
+// Because the key is fixed for a run, sending the same event (same product, version,
+// environment and status) twice within one CI run records it once: the API answers
+// the second request as a replay. Use --idempotency-key to record both.
+func deriveIdempotencyKey(kind, product, version, environment, eventStatus, invokeID, retryID string) string {
+	if invokeID == "" {
+		invokeID = "local-" + getInvocationNonce()
+	}
+
+	parts := []string{kind, product, version, environment, eventStatus, invokeID, retryID}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+
+	return "vk1-" + hex.EncodeToString(sum[:16])
+}
+
+// getInvocationNonce returns a random value that is fixed for the lifetime of the process
+func getInvocationNonce() string {
+	invocationNonceOnce.Do(func() {
+		buf := make([]byte, 16)
+		_, _ = rand.Read(buf)
+		invocationNonce = hex.EncodeToString(buf)
+	})
+	return invocationNonce
+}
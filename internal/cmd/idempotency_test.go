@@ -0,0 +1,35 @@
+package cmd
+
+import "testing"
+
+func TestDeriveIdempotencyKey(t *testing.T) {
+	key := deriveIdempotencyKey("deployment", "api", "1.2.3", "production", "started", "12345", "1")
+
+	if again := deriveIdempotencyKey("deployment", "api", "1.2.3", "production", "started", "12345", "1"); again != key {
+		t.Errorf("Expected stable key, got %s and %s", key, again)
+	}
+
+	different := []string{
+		deriveIdempotencyKey("build", "api", "1.2.3", "production", "started", "12345", "1"),
+		deriveIdempotencyKey("deployment", "api", "1.2.4", "production", "started", "12345", "1"),
+		deriveIdempotencyKey("deployment", "api", "1.2.3", "staging", "started", "12345", "1"),
+		deriveIdempotencyKey("deployment", "api", "1.2.3", "production", "completed", "12345", "1"),
+		deriveIdempotencyKey("deployment", "api", "1.2.3", "production", "started", "12346", "1"),
+		deriveIdempotencyKey("deployment", "api", "1.2.3", "production", "started", "12345", "2"),
+	}
+	for _, other := range different {
+		if other == key {
+			t.Errorf("Expected a different key for a different event, got %s", other)
+		}
+	}
+}
+
+func TestDeriveIdempotencyKey_WithoutInvokeID(t *testing.T) {
+	// Without an invocation ID the key is only stable within this process
+	first := deriveIdempotencyKey("build", "api", "1.2.3", "", "completed", "", "")
+	second := deriveIdempotencyKey("build", "api", "1.2.3", "", "completed", "", "")
+
+	if first != second {
+		t.Errorf("Expected stable key within a process, got %s and %s", first, second)
+	}
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Output formats supported by --output
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+	outputEnv  = "env"
+
+	// outputTable is accepted as an alias of text, which get commands once called table
+	outputTable = "table"
+)
+
+// outputFormat is the --output format of the running command (set by the root command)
+var outputFormat = outputText
+
+// parseOutputFormat validates an --output value and returns the format it selects
+func parseOutputFormat(format string) (string, error) {
+	if err := validateOutputFormat(format); err != nil {
+		return "", err
+	}
+	if format == outputTable {
+		return outputText, nil
+	}
+	return format, nil
+}
+
+// validateOutputFormat checks that format is one of the supported output formats
+func validateOutputFormat(format string) error {
+	switch format {
+	case outputText, outputJSON, outputYAML, outputEnv, outputTable:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q (must be one of: %s, %s, %s, %s)", format, outputText, outputJSON, outputYAML, outputEnv)
+	}
+}
+
+// structuredOutput reports whether the result of the command is written to stdout as a
+// JSON/YAML/env document instead of human-readable text
+func structuredOutput() bool {
+	return outputFormat != outputText
+}
+
+// envVar is one line of --output=env
+type envVar struct {
+	Name  string
+	Value string
+}
+
+// envExporter is implemented by results that can be written with --output=env
+type envExporter interface {
+	envVars() []envVar
+}
+
+// writeResult writes the structured result of a command to stdout in the selected format
+func writeResult(value interface{}) error {
+	return writeDocument(os.Stdout, outputFormat, value)
+}
+
+// writeDocument writes value to w as JSON, YAML or env lines
+func writeDocument(w io.Writer, format string, value interface{}) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(value)
+
+	case outputYAML:
+		return writeYAML(w, value)
+
+	case outputEnv:
+		exporter, ok := value.(envExporter)
+		if !ok {
+			return fmt.Errorf("--output=%s is not supported by this command", outputEnv)
+		}
+		for _, v := range exporter.envVars() {
+			if v.Value == "" {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s=%s\n", v.Name, shellQuote(v.Value)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("invalid output format %q", format)
+	}
+}
+
+// writeYAML writes value as YAML using the same field names and order as its JSON encoding
+func writeYAML(w io.Writer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	// JSON is valid YAML, so decoding it keeps the field order of the JSON encoding
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	resetYAMLStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// resetYAMLStyle switches a decoded JSON document to block style
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+// writeTable writes rows as an aligned table under headers
+func writeTable(w io.Writer, headers []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// safeShellValue matches values that need no quoting in env output
+var safeShellValue = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes value for POSIX shells if needed
+func shellQuote(value string) string {
+	if safeShellValue.MatchString(value) {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// formatTime formats an optional timestamp for table output
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// formatTimeRFC3339 formats an optional timestamp for env output
+func formatTimeRFC3339(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// orDash returns value, or "-" if it is empty
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
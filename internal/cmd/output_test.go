@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type testResult struct {
+	Version string `json:"version"`
+	Product string `json:"product"`
+	Note    string `json:"note,omitempty"`
+}
+
+func (r *testResult) envVars() []envVar {
+	return []envVar{{"VERSIONER_VERSION", r.Version}, {"VERSIONER_PRODUCT", r.Product}, {"VERSIONER_NOTE", r.Note}}
+}
+
+func TestWriteDocument(t *testing.T) {
+	value := &testResult{Version: "1.2", Product: "api service"}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{outputJSON, "{\n  \"version\": \"1.2\",\n  \"product\": \"api service\"\n}\n"},
+		// Field order follows the JSON encoding, and strings that look like numbers stay strings
+		{outputYAML, "version: \"1.2\"\nproduct: api service\n"},
+		// Empty values are left out, values with spaces are quoted
+		{outputEnv, "VERSIONER_VERSION=1.2\nVERSIONER_PRODUCT='api service'\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeDocument(&buf, tt.format, value); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Expected:\n%q\ngot:\n%q", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestWriteDocument_EnvNotSupported(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeDocument(&buf, outputEnv, []string{"a"}); err == nil {
+		t.Error("Expected an error for a value without env output")
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeTable(&buf, []string{"PRODUCT", "VERSION"}, [][]string{{"api-service", "1.2.3"}}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	want := "PRODUCT      VERSION\napi-service  1.2.3\n"
+	if buf.String() != want {
+		t.Errorf("Expected:\n%q\ngot:\n%q", want, buf.String())
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"https://app.versioner.io/x", "https://app.versioner.io/x"},
+		{"two words", "'two words'"},
+		{"it's", `'it'\''s'`},
+		{"$(rm -rf /)", "'$(rm -rf /)'"},
+	}
+
+	for _, tt := range tests {
+		if got := shellQuote(tt.value); got != tt.want {
+			t.Errorf("shellQuote(%q) = %s, expected %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	for _, format := range []string{outputText, outputJSON, outputYAML, outputEnv} {
+		if err := validateOutputFormat(format); err != nil {
+			t.Errorf("Expected %q to be valid, got: %v", format, err)
+		}
+	}
+	if err := validateOutputFormat("xml"); err == nil {
+		t.Error("Expected xml to be invalid")
+	}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	// table is kept as an alias of text for scripts written against get --output=table
+	if format, err := parseOutputFormat(outputTable); err != nil || format != outputText {
+		t.Errorf("Expected table to select text, got %q (%v)", format, err)
+	}
+	if format, err := parseOutputFormat(outputJSON); err != nil || format != outputJSON {
+		t.Errorf("Expected json, got %q (%v)", format, err)
+	}
+	if _, err := parseOutputFormat("xml"); err == nil {
+		t.Error("Expected xml to be invalid")
+	}
+}
+
+func TestParseTimeFlag(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"empty", "", time.Time{}, false},
+		{"timestamp", "2025-05-01T08:00:00Z", time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC), false},
+		{"duration", "24h", now.Add(-24 * time.Hour), false},
+		{"negative duration", "-1h", time.Time{}, true},
+		{"invalid", "yesterday", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeFlag(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
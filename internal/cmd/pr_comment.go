@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/github"
+	"github.com/versioner-io/versioner-cli/internal/report"
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+// prCommentHistoryLimit caps how many recent deployments of a product are searched for
+// deployments of the commit when building a pull request comment
+const prCommentHistoryLimit = 200
+
+// commentOnPullRequests upserts the deployment comment on the pull requests associated
+// with the deployed commit. Failures are reported as warnings - the event itself has
+// already been recorded.
+func commentOnPullRequests(ctx context.Context, client *api.Client, event *api.DeploymentEventCreate, eventID, uiURL string) {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "ℹ Not running in GitHub Actions - skipping pull request comment\n")
+		}
+		return
+	}
+
+	gh := github.NewClientFromEnv()
+	if gh == nil {
+		fmt.Fprintf(os.Stderr, "⚠ GITHUB_TOKEN is not set - skipping pull request comment\n")
+		return
+	}
+	if event.SCMSha == "" {
+		fmt.Fprintf(os.Stderr, "⚠ No commit SHA - skipping pull request comment\n")
+		return
+	}
+
+	pulls, err := gh.PullRequestsForCommit(ctx, event.SCMSha)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Failed to find pull requests for %s: %s\n", event.SCMSha, err.Error())
+		return
+	}
+	if len(pulls) == 0 {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "ℹ No pull requests found for %s\n", event.SCMSha)
+		}
+		return
+	}
+
+	history, err := client.ListDeployments(ctx, api.DeploymentFilter{
+		ProductName: event.ProductName,
+		Limit:       prCommentHistoryLimit,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Failed to list deployments for pull request comment: %s\n", err.Error())
+		return
+	}
+
+	rows := deploymentCommentRows(history, event, eventID, time.Now().UTC(), uiURL)
+	body := github.FormatDeploymentComment(event.ProductName, rows)
+	marker := github.DeploymentCommentMarker(event.ProductName)
+
+	for _, number := range pulls {
+		if err := gh.UpsertComment(ctx, number, marker, body); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ Failed to comment on pull request #%d: %s\n", number, err.Error())
+			continue
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "  Updated deployment comment on pull request #%d\n", number)
+		}
+	}
+}
+
+// deploymentCommentRows returns the latest deployment of the event's commit to each
+// environment, from the deployment history of the product (most recent first). The
+// event just recorded always wins, in case the history doesn't include it yet.
+func deploymentCommentRows(history []api.Deployment, event *api.DeploymentEventCreate, eventID string, now time.Time, uiURL string) []github.DeploymentRow {
+	latest := map[string]github.DeploymentRow{}
+
+	for i := range history {
+		d := &history[i]
+		if d.SCMSha != event.SCMSha {
+			continue
+		}
+		if _, seen := latest[d.EnvironmentName]; seen {
+			continue
+		}
+
+		row := github.DeploymentRow{
+			Environment: d.EnvironmentName,
+			Version:     d.Version,
+			Status:      status.GetCanonical(d.Status),
+			ViewURL:     report.ViewURL(uiURL, "Deployment", d.ID),
+		}
+		if t := deploymentTime(d); t != nil {
+			row.DeployedAt = *t
+		}
+		latest[d.EnvironmentName] = row
+	}
+
+	latest[event.EnvironmentName] = github.DeploymentRow{
+		Environment: event.EnvironmentName,
+		Version:     event.Version,
+		Status:      status.GetCanonical(event.Status),
+		DeployedAt:  now,
+		ViewURL:     report.ViewURL(uiURL, "Deployment", eventID),
+	}
+
+	rows := make([]github.DeploymentRow, 0, len(latest))
+	for _, row := range latest {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Environment < rows[j].Environment })
+	return rows
+}
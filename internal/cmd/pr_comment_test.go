@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/versioner-io/versioner-cli/internal/api"
+)
+
+func TestDeploymentCommentRows(t *testing.T) {
+	now := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	event := &api.DeploymentEventCreate{ProductName: "api", Version: "1.2.3", EnvironmentName: "production", Status: "success", SCMSha: "abc123"}
+
+	// Most recent first
+	history := []api.Deployment{
+		{ID: "dep-4", EnvironmentName: "staging", Version: "1.2.4", Status: "completed", SCMSha: "def456"},
+		{ID: "dep-3", EnvironmentName: "staging", Version: "1.2.3", Status: "completed", SCMSha: "abc123", CompletedAt: &earlier},
+		{ID: "dep-2", EnvironmentName: "staging", Version: "1.2.3", Status: "failed", SCMSha: "abc123"},
+		{ID: "dep-1", EnvironmentName: "production", Version: "1.2.3", Status: "started", SCMSha: "abc123"},
+	}
+
+	rows := deploymentCommentRows(history, event, "evt-9", now, "https://app.versioner.io")
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d: %+v", len(rows), rows)
+	}
+
+	production, staging := rows[0], rows[1]
+	if production.Environment != "production" || production.Status != "completed" || !production.DeployedAt.Equal(now) {
+		t.Errorf("Expected the tracked event for production, got %+v", production)
+	}
+	if production.ViewURL != "https://app.versioner.io/manage/deployments?view=evt-9" {
+		t.Errorf("Expected a link to the tracked event, got %s", production.ViewURL)
+	}
+	if staging.Environment != "staging" || staging.Version != "1.2.3" || staging.Status != "completed" || !staging.DeployedAt.Equal(earlier) {
+		t.Errorf("Expected the latest staging deployment of the commit, got %+v", staging)
+	}
+}
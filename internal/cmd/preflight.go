@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+var preflightCmd = &cobra.Command{
+	Use:   "preflight",
+	Short: "Check whether a deployment would be allowed, without recording it",
+	Long: `Evaluate deployment rules (preflight checks) for a deployment without
+recording a deployment event or taking a deployment lock.
+
+The same checks run when a 'started' deployment event is tracked:
+- No concurrent deployments (409 Conflict)
+- No-deploy windows/schedules (423 Locked)
+- Flow requirements, soak time, approvals (428 Precondition Required)
+
+Rules in report_only mode never block, but their failures are listed as warnings.
+
+Exit codes:
+  0 - Deployment would be allowed
+  1 - General error (network, invalid arguments)
+  4 - API error (validation, authentication)
+  5 - Deployment would be blocked`,
+	Example: `  # Check whether version 1.2.3 could be deployed to production right now
+  versioner preflight \
+    --product=api-service \
+    --environment=production \
+    --version=1.2.3`,
+	Args:    cobra.NoArgs,
+	PreRunE: loadProjectConfig,
+	RunE:    runPreflight,
+}
+
+func init() {
+	rootCmd.AddCommand(preflightCmd)
+
+	addDeploymentFlags(preflightCmd)
+}
+
+func runPreflight(cmd *cobra.Command, args []string) error {
+	// Auto-detect CI/CD environment
+	detected := cicd.Detect()
+
+	event, err := buildDeploymentEvent(cmd, detected, status.Started)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient(cmd)
+	if err != nil {
+		return err
+	}
+	// A dry run must report API errors rather than pretend the deployment is allowed
+	client.FailOnAPIError = true
+	client.Spool = nil
+
+	if verbose {
+		printDeploymentEvent(event, detected, client.BaseURL)
+	}
+
+	result, err := client.CheckPreflight(cmd.Context(), event)
+	if err != nil {
+		exitDeploymentError(event, err)
+	}
+
+	handlePreflightWarnings(result.Warnings)
+	fmt.Fprintf(os.Stderr, "✓ Preflight checks passed: %s %s can be deployed to %s\n", event.ProductName, event.Version, event.EnvironmentName)
+
+	return reportResult(newDeploymentResult(event, result, nil))
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import "testing"
+
+func TestPreflightCommands_RegisterDeploymentFlags(t *testing.T) {
+	// Every flag buildDeploymentEvent reads must be registered, or it is silently ignored
+	flags := []string{
+		"product", "environment", "version", "version-from", "build-number", "scm-sha", "scm-repository",
+		"source-system", "deploy-url", "invoke-id", "deployed-by", "deployed-by-email", "deployed-by-name",
+		"extra-metadata",
+	}
+
+	for _, name := range []string{"preflight", "wait"} {
+		c, _, err := rootCmd.Find([]string{name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, flag := range flags {
+			if c.Flags().Lookup(flag) == nil {
+				t.Errorf("%s does not register --%s", name, flag)
+			}
+		}
+		// Nothing is recorded, so the submission flags don't apply
+		if c.Flags().Lookup("idempotency-key") != nil {
+			t.Errorf("%s registers --idempotency-key", name)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/userconfig"
+)
+
+// activeProfile is the name of the config profile in use, or "" for the top-level settings
+var activeProfile string
+
+// applyProfile selects the profile named by --profile, VERSIONER_PROFILE or the config
+// file's profile key, and merges its settings over the config file's top-level settings.
+// Flags and environment variables still take precedence.
+func applyProfile() error {
+	name := viper.GetString("profile")
+	if name == "" {
+		return nil
+	}
+
+	// Viper lowercases keys, so profile names are case-insensitive
+	raw, ok := viper.GetStringMap("profiles")[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("profile %q not found in %s (see 'versioner config profiles list')", name, configFilePath())
+	}
+	if settings, ok := raw.(map[string]interface{}); ok {
+		if err := viper.MergeConfigMap(settings); err != nil {
+			return fmt.Errorf("invalid profile %q: %w", name, err)
+		}
+	}
+
+	activeProfile = name
+	if verbose {
+		fmt.Fprintf(os.Stderr, "Using profile: %s\n", name)
+	}
+	return nil
+}
+
+// configFilePath returns the config file in use: the --config file, the file viper
+// found, or else the default location
+func configFilePath() string {
+	if cfgFile != "" {
+		return cfgFile
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used
+	}
+	return defaultConfigFile()
+}
+
+// writableConfigFile returns the config file that config commands change: the --config
+// file, or else the one in ~/.versioner. A config file that viper found in the working
+// directory is only read, as it may be an unrelated file of the repository.
+func writableConfigFile() string {
+	if cfgFile != "" {
+		return cfgFile
+	}
+	path := defaultConfigFile()
+	used := viper.ConfigFileUsed()
+	if used == "" {
+		return path
+	}
+	if filepath.Dir(used) == filepath.Dir(path) {
+		return used
+	}
+	fmt.Fprintf(os.Stderr, "⚠ Not changing %s; writing %s instead (use --config to change another file)\n", used, path)
+	return path
+}
+
+// defaultConfigFile returns $HOME/.versioner/config.yaml
+func defaultConfigFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".versioner", "config.yaml")
+	}
+	return filepath.Join(home, ".versioner", "config.yaml")
+}
+
+// profileFile is the part of the config file describing profiles
+type profileFile struct {
+	Profile  string                            `yaml:"profile"`
+	Profiles map[string]map[string]interface{} `yaml:"profiles"`
+}
+
+// loadProfiles reads the profiles declared in the config file at path
+func loadProfiles(path string) (*userconfig.Document, *profileFile, error) {
+	doc, err := userconfig.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var profiles profileFile
+	if err := doc.Decode(&profiles); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", doc.Path, err)
+	}
+	return doc, &profiles, nil
+}
+
+// profileNames returns the names of the profiles in order
+func (p *profileFile) profileNames() []string {
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// profile returns the named profile's settings, matching names case-insensitively as
+// viper does
+func (p *profileFile) profile(name string) (string, map[string]interface{}, bool) {
+	for candidate, settings := range p.Profiles {
+		if strings.EqualFold(candidate, name) {
+			return candidate, settings, true
+		}
+	}
+	return "", nil, false
+}
+
+// redactSecret hides all but the last four characters of a secret
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
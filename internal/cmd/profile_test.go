@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestApplyProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "api_key: default-key\napi_url: https://api.versioner.io\nprofile: unit-a\nprofiles:\n  unit-a:\n    api_key: unit-a-key\n  Staging:\n    api_url: https://api.staging.versioner.io\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	load := func() {
+		t.Helper()
+		viper.Reset()
+		activeProfile = ""
+		viper.SetConfigFile(path)
+		viper.SetEnvPrefix("VERSIONER")
+		viper.AutomaticEnv()
+		if err := viper.ReadInConfig(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		viper.Reset()
+		activeProfile = ""
+	})
+
+	// The config file's default profile
+	load()
+	if err := applyProfile(); err != nil {
+		t.Fatal(err)
+	}
+	if activeProfile != "unit-a" || viper.GetString("api_key") != "unit-a-key" || viper.GetString("api_url") != "https://api.versioner.io" {
+		t.Errorf("Expected unit-a over the top-level settings, got %s: %s %s", activeProfile, viper.GetString("api_key"), viper.GetString("api_url"))
+	}
+
+	// VERSIONER_PROFILE overrides it, and environment variables override the profile
+	t.Setenv("VERSIONER_PROFILE", "staging")
+	t.Setenv("VERSIONER_API_URL", "http://localhost:8000")
+	load()
+	if err := applyProfile(); err != nil {
+		t.Fatal(err)
+	}
+	if activeProfile != "staging" || viper.GetString("api_key") != "default-key" || viper.GetString("api_url") != "http://localhost:8000" {
+		t.Errorf("Expected staging below env vars, got %s: %s %s", activeProfile, viper.GetString("api_key"), viper.GetString("api_url"))
+	}
+
+	t.Setenv("VERSIONER_PROFILE", "missing")
+	load()
+	if err := applyProfile(); err == nil {
+		t.Error("Expected an error for an unknown profile")
+	}
+}
+
+func TestRedactSecret(t *testing.T) {
+	tests := map[string]string{
+		"":                   "",
+		"short":              "****",
+		"sk_live_1234567890": "****7890",
+	}
+	for secret, expected := range tests {
+		if got := redactSecret(secret); got != expected {
+			t.Errorf("redactSecret(%q) = %q, expected %q", secret, got, expected)
+		}
+	}
+}
+
+func TestWritableConfigFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	work := t.TempDir()
+	t.Chdir(work)
+	if err := os.WriteFile(filepath.Join(work, "config.yaml"), []byte("unrelated: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(viper.Reset)
+
+	read := func() {
+		t.Helper()
+		viper.Reset()
+		viper.AddConfigPath(filepath.Join(home, ".versioner"))
+		viper.AddConfigPath(".")
+		viper.SetConfigType("yaml")
+		viper.SetConfigName("config")
+		_ = viper.ReadInConfig()
+	}
+	defaultPath := filepath.Join(home, ".versioner", "config.yaml")
+
+	// A config.yaml in the working directory is read, but changes go to ~/.versioner
+	read()
+	if used := configFilePath(); used != filepath.Join(work, "config.yaml") {
+		t.Fatalf("Expected the working directory's config.yaml to be read, got %s", used)
+	}
+	if path := writableConfigFile(); path != defaultPath {
+		t.Errorf("Expected %s, got %s", defaultPath, path)
+	}
+
+	// The config file in ~/.versioner is changed in place
+	if err := os.MkdirAll(filepath.Dir(defaultPath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(defaultPath, []byte("api_key: key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	read()
+	if path := writableConfigFile(); path != defaultPath {
+		t.Errorf("Expected %s, got %s", defaultPath, path)
+	}
+
+	// --config is changed wherever it is
+	cfgFile = filepath.Join(work, "config.yaml")
+	t.Cleanup(func() { cfgFile = "" })
+	if path := writableConfigFile(); path != cfgFile {
+		t.Errorf("Expected %s, got %s", cfgFile, path)
+	}
+}
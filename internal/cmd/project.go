@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/projectconfig"
+)
+
+// project is the repository's .versioner.yaml, or nil if there is none
+var project *projectconfig.File
+
+// loadProject discovers the project config file above the working directory
+func loadProject() error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	project, err = projectconfig.Discover(dir)
+	if err != nil {
+		return fmt.Errorf("invalid project config: %w", err)
+	}
+	if project != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Using project config file: %s\n", project.Path)
+	}
+	return nil
+}
+
+// loadProjectConfig is the PreRunE of the commands that use the project config. Other
+// commands don't read it, so an invalid .versioner.yaml doesn't break them.
+func loadProjectConfig(cmd *cobra.Command, args []string) error {
+	return loadProject()
+}
+
+// projectProduct returns the product the project config declares for the working
+// directory, or ""
+func projectProduct() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return project.ProductFor(dir)
+}
+
+// eventEnvironment returns the environment a command sends events to: --to for promote,
+// otherwise --environment from the flag or viper config
+func eventEnvironment(cmd *cobra.Command) string {
+	if cmd.Name() == "promote" {
+		to, _ := cmd.Flags().GetString("to")
+		return to
+	}
+	if cmd.Flags().Lookup("environment") == nil {
+		return ""
+	}
+	environment, _ := cmd.Flags().GetString("environment")
+	if environment == "" {
+		environment = viper.GetString("environment")
+	}
+	return environment
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/projectconfig"
+)
+
+func TestFailOnAPIError_EnvironmentOverride(t *testing.T) {
+	f, err := projectconfig.Parse([]byte("environments:\n  production:\n    fail_on_api_error: true\n  sandbox:\n    fail_on_api_error: false\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	project = f
+	t.Cleanup(func() { project = nil })
+
+	newCommand := func(environment string) *cobra.Command {
+		c := &cobra.Command{Use: "deployment"}
+		c.Flags().String("environment", environment, "")
+		c.Flags().Bool("fail-on-api-error", true, "")
+		return c
+	}
+
+	tests := []struct {
+		environment string
+		flag        string
+		expected    bool
+	}{
+		{"production", "", true},
+		{"sandbox", "", false},
+		{"staging", "", true},
+		{"sandbox", "true", true},
+	}
+	for _, tt := range tests {
+		c := newCommand(tt.environment)
+		if tt.flag != "" {
+			_ = c.Flags().Set("fail-on-api-error", tt.flag)
+		}
+		if fail := failOnAPIError(c); fail != tt.expected {
+			t.Errorf("%s (flag %q): expected %v, got %v", tt.environment, tt.flag, tt.expected, fail)
+		}
+	}
+
+	// Promote sends events to --to
+	promote := &cobra.Command{Use: "promote"}
+	promote.Flags().String("to", "sandbox", "")
+	if env := eventEnvironment(promote); env != "sandbox" {
+		t.Errorf("Expected sandbox, got %q", env)
+	}
+}
+
+func TestLoadProjectConfig_OnlyEventCommands(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(projectconfig.FileName, []byte("version: 1\nnewer_key: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { project = nil })
+	t.Cleanup(viper.Reset)
+	viper.Set("output", outputText)
+
+	// Commands that don't use the project config work whatever it contains
+	if err := rootCmd.PersistentPreRunE(versionCmd, nil); err != nil {
+		t.Errorf("Expected no error before 'version', got: %v", err)
+	}
+	for _, args := range [][]string{{"version"}, {"flush"}, {"current"}, {"get", "deployments"}} {
+		c, _, err := rootCmd.Find(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.PreRunE != nil {
+			t.Errorf("%s loads the project config", c.CommandPath())
+		}
+	}
+
+	// Commands that use it report its errors
+	for _, args := range [][]string{{"track", "build"}, {"track", "deployment"}, {"exec"}, {"preflight"}, {"wait"}, {"promote"}, {"rollback"}} {
+		c, _, err := rootCmd.Find(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.PreRunE == nil {
+			t.Errorf("%s does not load the project config", c.CommandPath())
+			continue
+		}
+		if err := c.PreRunE(c, nil); err == nil {
+			t.Errorf("Expected %s to reject the invalid project config", c.CommandPath())
+		}
+	}
+}
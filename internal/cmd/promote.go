@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+var promoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Deploy the version currently in one environment to another",
+	Long: `Track a deployment of the version currently deployed to one environment
+(--from) to another environment (--to).
+
+The version, commit SHA, repository and build number are copied from the most
+recent completed deployment to the source environment, so the promotion records
+exactly what was tested there. The promoted deployment is linked to the source
+deployment with the vi_promoted_from metadata key.
+
+By default a 'started' event is recorded, which runs preflight checks for the
+target environment.
+
+Exit codes:
+  0   - Success
+  1   - General error (network, invalid arguments)
+  3   - Nothing has been deployed to the source environment
+  4   - API error (validation, authentication)
+  5   - Preflight check failure (deployment blocked)
+  130 - Cancelled (SIGINT/SIGTERM)`,
+	Example: `  # Promote whatever is in staging to production
+  versioner promote --product=api-service --from=staging --to=production
+
+  # Record the completed promotion after deploying
+  versioner promote --product=api-service --from=staging --to=production --status=completed`,
+	Args:    cobra.NoArgs,
+	PreRunE: loadProjectConfig,
+	RunE:    runPromote,
+}
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+
+	promoteCmd.Flags().String("product", "", "Product/application name (required)")
+	promoteCmd.Flags().String("from", "", "Environment to promote from (required)")
+	promoteCmd.Flags().String("to", "", "Environment to promote to (required)")
+	promoteCmd.Flags().String("status", status.Started, "Deployment status (pending, started, completed, failed, aborted)")
+	addDeploymentContextFlags(promoteCmd)
+	addDeploymentSubmitFlags(promoteCmd)
+}
+
+func runPromote(cmd *cobra.Command, args []string) error {
+	// Auto-detect CI/CD environment
+	detected := cicd.Detect()
+
+	product := resolveProduct(cmd, detected)
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	statusValue, _ := cmd.Flags().GetString("status")
+
+	if product == "" {
+		return fmt.Errorf("--product is required")
+	}
+	if from == "" {
+		return fmt.Errorf("--from is required")
+	}
+	if to == "" {
+		return fmt.Errorf("--to is required")
+	}
+	if from == to {
+		return fmt.Errorf("--from and --to must be different environments")
+	}
+
+	client, err := newAPIClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Queries fail on API errors whatever --fail-on-api-error says: there is nothing to
+	// promote without the source deployment
+	source, err := findCurrentDeployment(cmd.Context(), client, product, from)
+	if err != nil {
+		exitQueryError(err)
+	}
+	if source == nil {
+		exitNotDeployed(fmt.Sprintf("Nothing has been deployed: %s → %s", product, from))
+	}
+
+	event, err := newPromotionEvent(cmd, detected, product, from, to, source, statusValue)
+	if err != nil {
+		return err
+	}
+
+	wait, err := deploymentWaitOptions(cmd, event)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Promoting %s %s: %s → %s\n", product, event.Version, from, to)
+	if verbose {
+		printDeploymentEvent(event, detected, client.BaseURL)
+	}
+
+	resp := submitDeploymentEvent(cmd, client, event, wait)
+	handlePreflightWarnings(resp.Warnings)
+
+	if resp.Status == api.StatusQueued {
+		fmt.Fprintf(os.Stderr, "⚠ Promotion queued for later delivery\n")
+	} else {
+		fmt.Fprintf(os.Stderr, "✓ Promotion tracked successfully\n")
+		fmt.Fprintf(os.Stderr, "  Version: %s\n", event.Version)
+		fmt.Fprintf(os.Stderr, "  Event ID: %s\n", resp.ID)
+	}
+
+	return reportResult(newDeploymentResult(event, resp, nil))
+}
+
+// newPromotionEvent builds the deployment event that promotes the source deployment from one environment to another
+func newPromotionEvent(cmd *cobra.Command, detected *cicd.DetectedValues, product, from, to string, source *api.Deployment, statusValue string) (*api.DeploymentEventCreate, error) {
+	event, err := newDeploymentEvent(cmd, detected, product, to, source.Version, statusValue)
+	if err != nil {
+		return nil, err
+	}
+
+	copyDeployedVersion(event, source)
+	event.ExtraMetadata["vi_promoted_from"] = source.ID
+	event.ExtraMetadata["vi_promoted_from_environment"] = from
+	applySubmitFlags(cmd, detected, event)
+
+	return event, nil
+}
+
+// copyDeployedVersion copies the build and SCM data of an earlier deployment of the same
+// version into event, so it describes the deployed version rather than the commit the
+// current job happens to run on. It also makes sure event has a metadata map.
+func copyDeployedVersion(event *api.DeploymentEventCreate, d *api.Deployment) {
+	event.BuildNumber = d.BuildNumber
+	event.SCMSha = d.SCMSha
+	event.SCMRepository = d.SCMRepository
+
+	if event.ExtraMetadata == nil {
+		event.ExtraMetadata = map[string]interface{}{}
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+)
+
+func TestNewPromotionEvent(t *testing.T) {
+	c := &cobra.Command{}
+	addDeploymentContextFlags(c)
+	addDeploymentSubmitFlags(c)
+	_ = c.Flags().Set("extra-metadata", `{"ticket": "OPS-1"}`)
+
+	detected := &cicd.DetectedValues{SCMSha: "promotion-job-sha", BuildNumber: "999"}
+	source := &api.Deployment{
+		ID:            "dep-123",
+		Version:       "1.2.3",
+		BuildNumber:   "42",
+		SCMSha:        "abc123",
+		SCMRepository: "acme/api-service",
+	}
+
+	event, err := newPromotionEvent(c, detected, "api-service", "staging", "production", source, "started")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if event.ProductName != "api-service" || event.EnvironmentName != "production" || event.Version != "1.2.3" {
+		t.Errorf("Expected api-service 1.2.3 → production, got %s %s → %s", event.ProductName, event.Version, event.EnvironmentName)
+	}
+	if event.SCMSha != "abc123" || event.BuildNumber != "42" || event.SCMRepository != "acme/api-service" {
+		t.Errorf("Expected SCM data of the source deployment, got sha=%s build=%s repo=%s", event.SCMSha, event.BuildNumber, event.SCMRepository)
+	}
+	if event.ExtraMetadata["vi_promoted_from"] != "dep-123" {
+		t.Errorf("Expected vi_promoted_from=dep-123, got %v", event.ExtraMetadata["vi_promoted_from"])
+	}
+	if event.ExtraMetadata["vi_promoted_from_environment"] != "staging" {
+		t.Errorf("Expected vi_promoted_from_environment=staging, got %v", event.ExtraMetadata["vi_promoted_from_environment"])
+	}
+	if event.ExtraMetadata["ticket"] != "OPS-1" {
+		t.Errorf("Expected user metadata to be kept, got %v", event.ExtraMetadata)
+	}
+	if event.IdempotencyKey == "" {
+		t.Error("Expected an idempotency key")
+	}
+}
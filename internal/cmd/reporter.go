@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/report"
+
+	// CI reporters register themselves for their system
+	_ "github.com/versioner-io/versioner-cli/internal/azure"
+	_ "github.com/versioner-io/versioner-cli/internal/buildkite"
+	_ "github.com/versioner-io/versioner-cli/internal/github"
+	_ "github.com/versioner-io/versioner-cli/internal/gitlab"
+)
+
+// ciReporter returns the reporters for the detected CI system and the global ones.
+// Outside a supported CI system only the global reporters are used.
+func ciReporter() report.Reporter {
+	return report.ForSystem(cicd.DetectSystem())
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/report"
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+// Preflight results reported in structured output
+const (
+	preflightPassed  = "passed"
+	preflightBlocked = "blocked"
+	preflightSkipped = "skipped"
+)
+
+// eventResult is the structured output of commands that record or check events
+type eventResult struct {
+	Event           interface{}  `json:"event,omitempty"`
+	Response        interface{}  `json:"response,omitempty"`
+	PreflightResult string       `json:"preflight_result,omitempty"`
+	ExitCode        *int         `json:"exit_code,omitempty"`
+	Error           *errorResult `json:"error,omitempty"`
+}
+
+// errorResult describes a failed request in structured output
+type errorResult struct {
+	StatusCode int                    `json:"status_code,omitempty"`
+	Type       string                 `json:"type,omitempty"`
+	Code       string                 `json:"code,omitempty"`
+	Message    string                 `json:"message"`
+	RetryAfter string                 `json:"retry_after,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+}
+
+// reportResult publishes the outcome of an event command: to the CI reporters (step
+// outputs under GitHub Actions, a dotenv report under GitLab CI, ...) and as the result
+// document on stdout with --output
+func reportResult(result *eventResult) error {
+	ciReporter().Result(result.ciResult(viper.GetString("ui_url")))
+
+	if structuredOutput() {
+		return writeResult(result)
+	}
+	return nil
+}
+
+// newErrorResult describes err, including preflight details for preflight rejections
+func newErrorResult(err error) *errorResult {
+	result := &errorResult{Message: err.Error()}
+
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		result.StatusCode = apiErr.StatusCode
+		if errorType, message, code, retryAfter, details, ok := apiErr.GetPreflightDetails(); ok {
+			result.Type = errorType
+			result.Code = code
+			result.RetryAfter = retryAfter
+			result.Details = details
+			if message != "" {
+				result.Message = message
+			}
+		}
+	} else if errors.Is(err, context.Canceled) {
+		result.Type = "cancelled"
+	}
+
+	return result
+}
+
+// newDeploymentResult describes the outcome of submitting (or checking) a deployment event
+func newDeploymentResult(event *api.DeploymentEventCreate, resp interface{}, err error) *eventResult {
+	result := &eventResult{Event: event, PreflightResult: deploymentPreflightResult(event, err)}
+	if resp != nil {
+		result.Response = resp
+	}
+	if err != nil {
+		result.Error = newErrorResult(err)
+	}
+
+	// Nothing was evaluated if the event never reached the API
+	if r, ok := resp.(*api.DeploymentResponse); ok && (r.Status == api.StatusQueued || r.Status == api.StatusNotRecorded) {
+		result.PreflightResult = ""
+	}
+
+	return result
+}
+
+// deploymentPreflightResult returns the preflight outcome of submitting event, or ""
+// if the event does not trigger preflight checks
+func deploymentPreflightResult(event *api.DeploymentEventCreate, err error) string {
+	if status.GetCanonical(event.Status) != status.Started {
+		return ""
+	}
+	if event.SkipPreflightChecks {
+		return preflightSkipped
+	}
+
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) && apiErr.IsPreflightError() {
+		return preflightBlocked
+	}
+	if err != nil {
+		return ""
+	}
+	return preflightPassed
+}
+
+func (r *eventResult) envVars() []envVar {
+	var vars []envVar
+
+	switch resp := r.Response.(type) {
+	case *api.DeploymentResponse:
+		vars = append(vars,
+			envVar{"VERSIONER_EVENT_ID", resp.ID},
+			envVar{"VERSIONER_PRODUCT_ID", resp.ProductID},
+			envVar{"VERSIONER_VERSION_ID", resp.VersionID},
+			envVar{"VERSIONER_ENVIRONMENT_ID", resp.EnvironmentID},
+			envVar{"VERSIONER_STATUS", resp.Status},
+			envVar{"VERSIONER_WARNING_COUNT", countString(len(resp.Warnings))},
+		)
+	case *api.BuildResponse:
+		vars = append(vars,
+			envVar{"VERSIONER_EVENT_ID", resp.ID},
+			envVar{"VERSIONER_PRODUCT_ID", resp.ProductID},
+			envVar{"VERSIONER_VERSION_ID", resp.VersionID},
+			envVar{"VERSIONER_STATUS", resp.Status},
+		)
+	case *api.PreflightResponse:
+		vars = append(vars, envVar{"VERSIONER_WARNING_COUNT", countString(len(resp.Warnings))})
+	}
+
+	switch event := r.Event.(type) {
+	case *api.DeploymentEventCreate:
+		vars = append(vars,
+			envVar{"VERSIONER_PRODUCT", event.ProductName},
+			envVar{"VERSIONER_VERSION", event.Version},
+			envVar{"VERSIONER_ENVIRONMENT", event.EnvironmentName},
+		)
+	case *api.BuildEventCreate:
+		vars = append(vars,
+			envVar{"VERSIONER_PRODUCT", event.ProductName},
+			envVar{"VERSIONER_VERSION", event.Version},
+		)
+	}
+
+	vars = append(vars, envVar{"VERSIONER_PREFLIGHT_RESULT", r.PreflightResult})
+	if r.ExitCode != nil {
+		vars = append(vars, envVar{"VERSIONER_EXIT_CODE", strconv.Itoa(*r.ExitCode)})
+	}
+
+	if r.Error != nil {
+		vars = append(vars,
+			envVar{"VERSIONER_ERROR", r.Error.Message},
+			envVar{"VERSIONER_ERROR_CODE", r.Error.Code},
+			envVar{"VERSIONER_RETRY_AFTER", r.Error.RetryAfter},
+		)
+	}
+
+	return vars
+}
+
+// ciResult returns the outcome passed to CI reporters for later steps
+func (r *eventResult) ciResult(uiURL string) report.Result {
+	outputs := report.Result{PreflightResult: r.PreflightResult}
+
+	switch resp := r.Response.(type) {
+	case *api.DeploymentResponse:
+		outputs.EventID = resp.ID
+		outputs.VersionID = resp.VersionID
+		outputs.EnvironmentID = resp.EnvironmentID
+		outputs.Status = resp.Status
+		outputs.UIURL = report.ViewURL(uiURL, "Deployment", resp.ID)
+	case *api.BuildResponse:
+		outputs.EventID = resp.ID
+		outputs.VersionID = resp.VersionID
+		outputs.Status = resp.Status
+		outputs.UIURL = report.ViewURL(uiURL, "Build", resp.VersionID)
+	}
+
+	if r.Error != nil {
+		outputs.RetryAfter = r.Error.RetryAfter
+	}
+
+	for _, v := range r.ciVariables(outputs.UIURL) {
+		outputs.Variables = append(outputs.Variables, report.Variable(v))
+	}
+
+	return outputs
+}
+
+// ciVariables returns the variables passed to later CI jobs (GitLab dotenv report, Azure
+// pipeline variables): the same variables as --output=env, plus the link to the event
+// in the Versioner UI
+func (r *eventResult) ciVariables(uiURL string) []envVar {
+	return append(r.envVars(), envVar{"VERSIONER_UI_URL", uiURL})
+}
+
+// countString formats a count for env output, omitting zero
+func countString(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
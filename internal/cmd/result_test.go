@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/versioner-io/versioner-cli/internal/api"
+)
+
+func TestNewDeploymentResult(t *testing.T) {
+	started := &api.DeploymentEventCreate{ProductName: "api", Version: "1.2.3", EnvironmentName: "production", Status: "started"}
+	completed := &api.DeploymentEventCreate{ProductName: "api", Version: "1.2.3", EnvironmentName: "production", Status: "success"}
+	skipped := &api.DeploymentEventCreate{ProductName: "api", Version: "1.2.3", EnvironmentName: "production", Status: "started", SkipPreflightChecks: true}
+	blocked := &api.APIError{StatusCode: 423, Detail: map[string]interface{}{
+		"error": "Deployment blocked", "message": "No deploys on Fridays", "code": "NO_DEPLOY_WINDOW", "retry_after": "2025-01-06T09:00:00Z",
+	}}
+
+	tests := []struct {
+		name          string
+		event         *api.DeploymentEventCreate
+		resp          interface{}
+		err           error
+		wantPreflight string
+		wantCode      string
+	}{
+		{"started and allowed", started, &api.DeploymentResponse{ID: "evt-1"}, nil, preflightPassed, ""},
+		{"started and blocked", started, nil, blocked, preflightBlocked, "NO_DEPLOY_WINDOW"},
+		{"preflight skipped", skipped, &api.DeploymentResponse{ID: "evt-1"}, nil, preflightSkipped, ""},
+		{"completed events are not checked", completed, &api.DeploymentResponse{ID: "evt-1"}, nil, "", ""},
+		{"queued events were not checked", started, &api.DeploymentResponse{Status: api.StatusQueued}, nil, "", ""},
+		{"network error", started, nil, errors.New("connection refused"), "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := newDeploymentResult(tt.event, tt.resp, tt.err)
+
+			if result.PreflightResult != tt.wantPreflight {
+				t.Errorf("Expected preflight result %q, got %q", tt.wantPreflight, result.PreflightResult)
+			}
+			if (result.Error != nil) != (tt.err != nil) {
+				t.Fatalf("Expected error=%v, got %+v", tt.err != nil, result.Error)
+			}
+			if result.Error != nil && result.Error.Code != tt.wantCode {
+				t.Errorf("Expected error code %q, got %q", tt.wantCode, result.Error.Code)
+			}
+		})
+	}
+}
+
+func TestEventResultEnvVars(t *testing.T) {
+	event := &api.DeploymentEventCreate{ProductName: "api", Version: "1.2.3", EnvironmentName: "production", Status: "started"}
+	blocked := &api.APIError{StatusCode: 423, Detail: map[string]interface{}{
+		"message": "No deploys on Fridays", "code": "NO_DEPLOY_WINDOW", "retry_after": "2025-01-06T09:00:00Z",
+	}}
+
+	vars := map[string]string{}
+	for _, v := range newDeploymentResult(event, nil, blocked).envVars() {
+		if v.Value != "" {
+			vars[v.Name] = v.Value
+		}
+	}
+
+	expected := map[string]string{
+		"VERSIONER_VERSION":          "1.2.3",
+		"VERSIONER_ENVIRONMENT":      "production",
+		"VERSIONER_PREFLIGHT_RESULT": preflightBlocked,
+		"VERSIONER_ERROR":            "No deploys on Fridays",
+		"VERSIONER_ERROR_CODE":       "NO_DEPLOY_WINDOW",
+		"VERSIONER_RETRY_AFTER":      "2025-01-06T09:00:00Z",
+	}
+	for name, want := range expected {
+		if vars[name] != want {
+			t.Errorf("Expected %s=%s, got %q", name, want, vars[name])
+		}
+	}
+	if _, ok := vars["VERSIONER_EVENT_ID"]; ok {
+		t.Error("Expected no event ID for a rejected event")
+	}
+}
+
+func TestEventResultCIResult(t *testing.T) {
+	event := &api.DeploymentEventCreate{ProductName: "api", Version: "1.2.3", EnvironmentName: "production", Status: "started"}
+	resp := &api.DeploymentResponse{ID: "evt-1", VersionID: "ver-1", EnvironmentID: "env-1", Status: "started"}
+
+	outputs := newDeploymentResult(event, resp, nil).ciResult("https://app.versioner.io")
+	if outputs.EventID != "evt-1" || outputs.VersionID != "ver-1" || outputs.EnvironmentID != "env-1" {
+		t.Errorf("Expected IDs from the response, got %+v", outputs)
+	}
+	if outputs.PreflightResult != preflightPassed {
+		t.Errorf("Expected preflight result %q, got %q", preflightPassed, outputs.PreflightResult)
+	}
+	if want := "https://app.versioner.io/manage/deployments?view=evt-1"; outputs.UIURL != want {
+		t.Errorf("Expected ui_url %s, got %s", want, outputs.UIURL)
+	}
+
+	build := &eventResult{Response: &api.BuildResponse{ID: "evt-2", VersionID: "ver-2", Status: "completed"}}
+	if want := "https://app.versioner.io/manage/versions?view=ver-2"; build.ciResult("https://app.versioner.io").UIURL != want {
+		t.Errorf("Expected build ui_url %s, got %s", want, build.ciResult("https://app.versioner.io").UIURL)
+	}
+
+	blocked := &api.APIError{StatusCode: 423, Detail: map[string]interface{}{
+		"message": "No deploys on Fridays", "code": "NO_DEPLOY_WINDOW", "retry_after": "2025-01-06T09:00:00Z",
+	}}
+	outputs = newDeploymentResult(event, nil, blocked).ciResult("https://app.versioner.io")
+	if outputs.RetryAfter != "2025-01-06T09:00:00Z" || outputs.PreflightResult != preflightBlocked {
+		t.Errorf("Expected blocked with retry_after, got %+v", outputs)
+	}
+	if outputs.EventID != "" || outputs.UIURL != "" {
+		t.Errorf("Expected no event ID or ui_url for a rejected event, got %+v", outputs)
+	}
+}
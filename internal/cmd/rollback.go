@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+// rollbackHistoryLimit caps how many completed deployments are searched for a rollback target
+const rollbackHistoryLimit = 500
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Roll an environment back to its previous successful version",
+	Long: `Track a rollback of an environment to the version deployed before the current one.
+
+The deployment history of the environment is searched for the most recent
+completed deployment of a different version than the current one, or of the
+version given with --to. Versions that an earlier rollback replaced are skipped,
+so rolling back twice goes back two good versions. The target version is printed to stdout for the deploy
+tooling, and a deployment event of that version is recorded with rollback
+metadata (vi_rollback, vi_rollback_from_version, vi_rollback_to_deployment) so
+rollbacks can be told apart from forward deploys.
+
+By default a 'started' event is recorded, which runs preflight checks.
+
+Exit codes:
+  0   - Success
+  1   - General error (network, invalid arguments)
+  3   - No version to roll back to
+  4   - API error (validation, authentication)
+  5   - Preflight check failure (deployment blocked)
+  130 - Cancelled (SIGINT/SIGTERM)`,
+	Example: `  # Roll production back to the last good version and deploy it
+  VERSION=$(versioner rollback --product=api-service --environment=production)
+  ./deploy.sh "$VERSION"
+  versioner track deployment --product=api-service --environment=production \
+    --version="$VERSION" --status=completed
+
+  # Roll back to a specific earlier version
+  versioner rollback --product=api-service --environment=production --to=1.2.0`,
+	Args:    cobra.NoArgs,
+	PreRunE: loadProjectConfig,
+	RunE:    runRollback,
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+
+	rollbackCmd.Flags().String("product", "", "Product/application name (required)")
+	rollbackCmd.Flags().String("environment", "", "Environment to roll back (required)")
+	rollbackCmd.Flags().String("to", "", "Version to roll back to (default: the previous successful version)")
+	rollbackCmd.Flags().String("status", status.Started, "Deployment status (pending, started, completed, failed, aborted)")
+	addDeploymentContextFlags(rollbackCmd)
+	addDeploymentSubmitFlags(rollbackCmd)
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	// Auto-detect CI/CD environment
+	detected := cicd.Detect()
+
+	product := resolveProduct(cmd, detected)
+	environment, _ := cmd.Flags().GetString("environment")
+	toVersion, _ := cmd.Flags().GetString("to")
+	statusValue, _ := cmd.Flags().GetString("status")
+
+	if product == "" {
+		return fmt.Errorf("--product is required")
+	}
+	if environment == "" {
+		return fmt.Errorf("--environment is required")
+	}
+
+	client, err := newAPIClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Queries fail on API errors whatever --fail-on-api-error says: the rollback target
+	// can't be chosen without the history
+	history, err := client.ListDeployments(cmd.Context(), api.DeploymentFilter{
+		ProductName:     product,
+		EnvironmentName: environment,
+		Status:          status.Completed,
+		Limit:           rollbackHistoryLimit,
+	})
+	if err != nil {
+		exitQueryError(err)
+	}
+
+	current, target, err := findRollbackTarget(history, toVersion)
+	if err != nil {
+		exitNotDeployed(fmt.Sprintf("Cannot roll back %s in %s: %s", product, environment, err.Error()))
+	}
+
+	event, err := newDeploymentEvent(cmd, detected, product, environment, target.Version, statusValue)
+	if err != nil {
+		return err
+	}
+	copyDeployedVersion(event, target)
+	for k, v := range rollbackMetadata(current, target) {
+		event.ExtraMetadata[k] = v
+	}
+	applySubmitFlags(cmd, detected, event)
+
+	wait, err := deploymentWaitOptions(cmd, event)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Rolling back %s in %s: %s → %s\n", product, environment, current.Version, target.Version)
+	if verbose {
+		printDeploymentEvent(event, detected, client.BaseURL)
+	}
+
+	resp := submitDeploymentEvent(cmd, client, event, wait)
+	handlePreflightWarnings(resp.Warnings)
+
+	if resp.Status == api.StatusQueued {
+		fmt.Fprintf(os.Stderr, "⚠ Rollback queued for later delivery\n")
+	} else {
+		fmt.Fprintf(os.Stderr, "✓ Rollback tracked successfully (Event ID: %s)\n", resp.ID)
+	}
+
+	if err := reportResult(newDeploymentResult(event, resp, nil)); err != nil {
+		return err
+	}
+	if structuredOutput() {
+		return nil
+	}
+
+	// Only the version goes to stdout, for $(...) substitutions
+	fmt.Println(target.Version)
+	return nil
+}
+
+// rollbackMetadata returns the metadata marking a deployment as a rollback from current to target
+func rollbackMetadata(current, target *api.Deployment) map[string]interface{} {
+	return map[string]interface{}{
+		"vi_rollback":               true,
+		"vi_rollback_from_version":  current.Version,
+		"vi_rollback_to_deployment": target.ID,
+	}
+}
+
+// rolledBackVersion returns the version a rollback deployment replaced, or "" if d is
+// not a rollback
+func rolledBackVersion(d *api.Deployment) string {
+	version, _ := d.ExtraMetadata["vi_rollback_from_version"].(string)
+	return version
+}
+
+// findRollbackTarget picks the deployment to roll back to from the completed deployment
+// history of an environment (most recent first): the most recent deployment of toVersion,
+// or if toVersion is empty, of the first version that differs from the current one and
+// was not itself rolled back. Skipping rolled-back versions makes a second rollback go
+// further back instead of returning to the release the first one undid.
+func findRollbackTarget(history []api.Deployment, toVersion string) (current, target *api.Deployment, err error) {
+	if len(history) == 0 {
+		return nil, nil, fmt.Errorf("nothing has been deployed")
+	}
+	current = &history[0]
+
+	if toVersion == current.Version {
+		return nil, nil, fmt.Errorf("version %s is already deployed", toVersion)
+	}
+
+	rolledBack := map[string]bool{}
+	if version := rolledBackVersion(current); version != "" {
+		rolledBack[version] = true
+	}
+
+	for i := range history[1:] {
+		d := &history[i+1]
+		skip := d.Version == current.Version || (toVersion == "" && rolledBack[d.Version])
+		if version := rolledBackVersion(d); version != "" {
+			rolledBack[version] = true
+		}
+		if skip {
+			continue
+		}
+		if toVersion == "" || d.Version == toVersion {
+			return current, d, nil
+		}
+	}
+
+	if toVersion != "" {
+		return nil, nil, fmt.Errorf("version %s was never successfully deployed", toVersion)
+	}
+	return nil, nil, fmt.Errorf("no earlier successful version")
+}
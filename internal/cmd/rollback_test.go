@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/versioner-io/versioner-cli/internal/api"
+)
+
+func TestFindRollbackTarget(t *testing.T) {
+	history := []api.Deployment{
+		{ID: "d5", Version: "1.3.0"},
+		{ID: "d4", Version: "1.3.0"},
+		{ID: "d3", Version: "1.2.1"},
+		{ID: "d2", Version: "1.2.0"},
+		{ID: "d1", Version: "1.1.0"},
+	}
+
+	tests := []struct {
+		name       string
+		history    []api.Deployment
+		toVersion  string
+		wantTarget string
+		wantErr    bool
+	}{
+		{"previous version skips redeploys of the current one", history, "", "d3", false},
+		{"specific version", history, "1.2.0", "d2", false},
+		{"version never deployed", history, "0.9.0", "", true},
+		{"version already deployed", history, "1.3.0", "", true},
+		{"only one version deployed", history[:2], "", "", true},
+		{"nothing deployed", nil, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, target, err := findRollbackTarget(tt.history, tt.toVersion)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if current.ID != "d5" {
+				t.Errorf("Expected current deployment d5, got %s", current.ID)
+			}
+			if target.ID != tt.wantTarget {
+				t.Errorf("Expected target %s, got %s", tt.wantTarget, target.ID)
+			}
+		})
+	}
+}
+
+func TestFindRollbackTarget_Twice(t *testing.T) {
+	history := []api.Deployment{
+		{ID: "d3", Version: "1.3.0"},
+		{ID: "d2", Version: "1.2.0"},
+		{ID: "d1", Version: "1.1.0"},
+	}
+
+	// rollback records a completed rollback deployment, as runRollback and the deploy that follows do
+	rollback := func(id string) string {
+		current, target, err := findRollbackTarget(history, "")
+		if err != nil {
+			t.Fatalf("Expected a rollback target, got %v", err)
+		}
+		deployment := api.Deployment{ID: id, Version: target.Version, ExtraMetadata: rollbackMetadata(current, target)}
+		history = append([]api.Deployment{deployment}, history...)
+		return target.Version
+	}
+
+	if version := rollback("r1"); version != "1.2.0" {
+		t.Fatalf("Expected the first rollback to 1.2.0, got %s", version)
+	}
+	// The second rollback must not return to 1.3.0, which the first one rolled back
+	if version := rollback("r2"); version != "1.1.0" {
+		t.Fatalf("Expected the second rollback to 1.1.0, got %s", version)
+	}
+	if _, _, err := findRollbackTarget(history, ""); err == nil {
+		t.Error("Expected no version left to roll back to")
+	}
+
+	// An explicit --to may still pick a rolled-back version
+	if _, target, err := findRollbackTarget(history, "1.3.0"); err != nil || target.ID != "d3" {
+		t.Errorf("Expected --to=1.3.0 to pick d3, got %v (%v)", target, err)
+	}
+}
@@ -1,11 +1,24 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/api"
+)
+
+// exitCodeCancelled is the exit code used when a command is cancelled by SIGINT or SIGTERM
+const exitCodeCancelled = 130
+
+// Default Versioner endpoints
+const (
+	defaultAPIURL = "https://api.versioner.io"
+	defaultUIURL  = "https://app.versioner.io"
 )
 
 var (
@@ -21,11 +34,22 @@ var rootCmd = &cobra.Command{
 	Long: `Versioner CLI is a command-line tool for tracking build and deployment events
 in your CI/CD pipelines. It sends events to the Versioner API for deployment
 tracking, visibility, and audit purposes.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		if outputFormat, err = parseOutputFormat(viper.GetString("output")); err != nil {
+			return err
+		}
+		return applyProfile()
+	},
 }
 
-// Execute runs the root command
+// Execute runs the root command. SIGINT and SIGTERM cancel the command's context,
+// which aborts in-flight API requests and retry backoff.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
@@ -35,16 +59,38 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.versioner/config.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "debug output (includes HTTP requests/responses)")
+	rootCmd.PersistentFlags().StringP("output", "o", outputText, "Output format: text, json, yaml or env (human-readable messages always go to stderr)")
+	rootCmd.PersistentFlags().String("profile", "", "Config file profile to use (see 'versioner config profiles')")
 
 	// API configuration flags
 	rootCmd.PersistentFlags().String("api-url", "", "Versioner API URL (default: https://api.versioner.io)")
 	rootCmd.PersistentFlags().String("api-key", "", "Versioner API key (prefer VERSIONER_API_KEY env var)")
 	rootCmd.PersistentFlags().String("ui-url", "", "Versioner UI URL (default: https://app.versioner.io)")
 
+	// Retry policy flags
+	rootCmd.PersistentFlags().Int("retry-max-attempts", 0, "Maximum number of API request attempts, including the first (default: 4)")
+	rootCmd.PersistentFlags().Duration("retry-base-backoff", 0, "Wait before the first retry, doubled on each retry (default: 1s)")
+	rootCmd.PersistentFlags().Duration("retry-max-backoff", 0, "Maximum wait between retries (default: 30s)")
+	rootCmd.PersistentFlags().Float64("retry-jitter", 0, "Fraction of each backoff to randomize, 0-1 (default: 0.1)")
+	rootCmd.PersistentFlags().Duration("retry-deadline", 0, "Total time allowed for all attempts of a request (default: 2m)")
+
+	// Offline spool flags
+	rootCmd.PersistentFlags().Bool("spool", true, "Queue events that cannot be delivered when --fail-on-api-error=false (replay with 'versioner flush')")
+	rootCmd.PersistentFlags().String("spool-dir", "", "Directory for queued events (default is $HOME/.versioner/spool)")
+
 	// Bind flags to viper
+	_ = viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+	_ = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
 	_ = viper.BindPFlag("api_url", rootCmd.PersistentFlags().Lookup("api-url"))
 	_ = viper.BindPFlag("api_key", rootCmd.PersistentFlags().Lookup("api-key"))
 	_ = viper.BindPFlag("ui_url", rootCmd.PersistentFlags().Lookup("ui-url"))
+	_ = viper.BindPFlag("retry_max_attempts", rootCmd.PersistentFlags().Lookup("retry-max-attempts"))
+	_ = viper.BindPFlag("retry_base_backoff", rootCmd.PersistentFlags().Lookup("retry-base-backoff"))
+	_ = viper.BindPFlag("retry_max_backoff", rootCmd.PersistentFlags().Lookup("retry-max-backoff"))
+	_ = viper.BindPFlag("retry_jitter", rootCmd.PersistentFlags().Lookup("retry-jitter"))
+	_ = viper.BindPFlag("retry_deadline", rootCmd.PersistentFlags().Lookup("retry-deadline"))
+	_ = viper.BindPFlag("spool", rootCmd.PersistentFlags().Lookup("spool"))
+	_ = viper.BindPFlag("spool_dir", rootCmd.PersistentFlags().Lookup("spool-dir"))
 }
 
 // initConfig reads in config file and ENV variables
@@ -72,8 +118,15 @@ func initConfig() {
 	viper.AutomaticEnv()
 
 	// Set defaults
-	viper.SetDefault("api_url", "https://api.versioner.io")
-	viper.SetDefault("ui_url", "https://app.versioner.io")
+	viper.SetDefault("api_url", defaultAPIURL)
+	viper.SetDefault("ui_url", defaultUIURL)
+
+	retryDefaults := api.DefaultRetryPolicy()
+	viper.SetDefault("retry_max_attempts", retryDefaults.MaxAttempts)
+	viper.SetDefault("retry_base_backoff", retryDefaults.BaseBackoff)
+	viper.SetDefault("retry_max_backoff", retryDefaults.MaxBackoff)
+	viper.SetDefault("retry_jitter", retryDefaults.Jitter)
+	viper.SetDefault("retry_deadline", retryDefaults.Deadline)
 
 	// Read config file if it exists
 	if err := viper.ReadInConfig(); err == nil && verbose {
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -9,7 +11,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/versioner-io/versioner-cli/internal/api"
 	"github.com/versioner-io/versioner-cli/internal/cicd"
-	"github.com/versioner-io/versioner-cli/internal/github"
+	"github.com/versioner-io/versioner-cli/internal/report"
 	"github.com/versioner-io/versioner-cli/internal/status"
 )
 
@@ -28,7 +30,8 @@ This command sends build information to track the build process.`,
     --status=completed \
     --scm-sha=abc123 \
     --build-number=456`,
-	RunE: runBuildTrack,
+	PreRunE: loadProjectConfig,
+	RunE:    runBuildTrack,
 }
 
 func init() {
@@ -37,6 +40,7 @@ func init() {
 	// Required flags
 	buildCmd.Flags().String("product", "", "Product/application name (required)")
 	buildCmd.Flags().String("version", "", "Version string (required)")
+	addVersionFromFlag(buildCmd)
 	buildCmd.Flags().String("status", "completed", "Build status (pending, started, completed, failed, aborted)")
 
 	// Optional flags
@@ -53,11 +57,13 @@ func init() {
 	buildCmd.Flags().String("started-at", "", "Build start timestamp (ISO 8601 format)")
 	buildCmd.Flags().String("completed-at", "", "Build completion timestamp (ISO 8601 format)")
 	buildCmd.Flags().String("extra-metadata", "", "Additional metadata as JSON object (max 100KB)")
+	buildCmd.Flags().String("idempotency-key", "", "Idempotency key for the event (default: derived from product, version, status and CI run)")
 	buildCmd.Flags().Bool("fail-on-api-error", true, "Fail command if API is unreachable or returns auth/validation errors (default: true)")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("product", buildCmd.Flags().Lookup("product"))
 	_ = viper.BindPFlag("version", buildCmd.Flags().Lookup("version"))
+	_ = viper.BindPFlag("version_from", buildCmd.Flags().Lookup("version-from"))
 	_ = viper.BindPFlag("status", buildCmd.Flags().Lookup("status"))
 	_ = viper.BindPFlag("source_system", buildCmd.Flags().Lookup("source-system"))
 	_ = viper.BindPFlag("build_number", buildCmd.Flags().Lookup("build-number"))
@@ -77,20 +83,11 @@ func runBuildTrack(cmd *cobra.Command, args []string) error {
 	detected := cicd.Detect()
 
 	// Get required fields (with auto-detection fallback)
-	product, _ := cmd.Flags().GetString("product")
-	if product == "" {
-		product = viper.GetString("product")
-	}
-	if product == "" {
-		product = detected.Product
-	}
+	product := resolveProduct(cmd, detected)
 
-	version, _ := cmd.Flags().GetString("version")
-	if version == "" {
-		version = viper.GetString("version")
-	}
-	if version == "" {
-		version = detected.Version
+	version, err := resolveVersion(cmd, detected, product)
+	if err != nil {
+		return err
 	}
 
 	statusValue, _ := cmd.Flags().GetString("status")
@@ -109,25 +106,11 @@ func runBuildTrack(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("--version is required")
 	}
 
-	// Get API configuration
-	apiURL := viper.GetString("api_url")
-	apiKey := viper.GetString("api_key")
-
-	if apiKey == "" {
-		return fmt.Errorf("API key is required. Set VERSIONER_API_KEY environment variable or use --api-key flag")
-	}
-
-	// Get fail-on-api-error flag (default: true)
-	failOnApiError, _ := cmd.Flags().GetBool("fail-on-api-error")
-	if !cmd.Flags().Changed("fail-on-api-error") {
-		failOnApiError = viper.GetBool("fail_on_api_error")
-		if !viper.IsSet("fail_on_api_error") {
-			failOnApiError = true
-		}
-	}
-
 	// Create API client
-	client := api.NewClient(apiURL, apiKey, debug, failOnApiError)
+	client, err := newAPIClient(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Helper function to get value with fallback (cmd flags -> viper -> auto-detected)
 	getWithFallback := func(flagName string, viperKey string, fallback string) string {
@@ -190,10 +173,19 @@ func runBuildTrack(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Merge metadata (user values take precedence)
-	event.ExtraMetadata = MergeMetadata(autoMetadata, userMetadata)
+	// Merge metadata (project config values override auto-detected ones, and user values
+	// take precedence over both)
+	event.ExtraMetadata = MergeMetadata(MergeMetadata(autoMetadata, project.Metadata(product, "")), userMetadata)
+
+	// Use the supplied idempotency key, or derive a stable one for this logical event
+	event.IdempotencyKey, _ = cmd.Flags().GetString("idempotency-key")
+	if event.IdempotencyKey == "" {
+		event.IdempotencyKey = deriveIdempotencyKey("build", event.ProductName, event.Version, "",
+			status.GetCanonical(event.Status), event.InvokeID, detected.RetryID())
+	}
 
 	if verbose {
+		ciReporter().StartSection("versioner_build_event", "Tracking build event")
 		fmt.Fprintf(os.Stderr, "Tracking build event:\n")
 		if detected.System != cicd.SystemUnknown {
 			fmt.Fprintf(os.Stderr, "  ℹ Auto-detected CI system: %s\n", detected.System)
@@ -210,36 +202,63 @@ func runBuildTrack(cmd *cobra.Command, args []string) error {
 		if event.SCMSha != "" {
 			fmt.Fprintf(os.Stderr, "  Commit SHA: %s\n", event.SCMSha)
 		}
-		fmt.Fprintf(os.Stderr, "  API URL: %s\n", apiURL)
+		fmt.Fprintf(os.Stderr, "  Idempotency Key: %s\n", event.IdempotencyKey)
+		fmt.Fprintf(os.Stderr, "  API URL: %s\n", client.BaseURL)
 		fmt.Fprintf(os.Stderr, "\n")
+		ciReporter().EndSection("versioner_build_event")
 	}
 
 	// Send the event
-	resp, err := client.CreateBuildEvent(event)
+	resp, err := client.CreateBuildEventWithContext(cmd.Context(), event)
 	if err != nil {
-		if apiErr, ok := err.(*api.APIError); ok {
-			// API error - exit code 2
-			github.WriteGenericErrorAnnotation("Build", "API Error", apiErr.Error())
-			fmt.Fprintf(os.Stderr, "API error: %s\n", apiErr.Error())
-			os.Exit(2)
-		}
-		// Network or other error - exit code 2
-		github.WriteGenericErrorAnnotation("Build", "Network Error", err.Error())
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
-		os.Exit(2)
+		code := reportBuildError(err)
+		_ = reportResult(&eventResult{Event: event, Error: newErrorResult(err)})
+		os.Exit(code)
 	}
 
-	// Success
-	fmt.Printf("✓ Build event tracked successfully\n")
-	fmt.Printf("  Event ID: %s\n", resp.ID)
-	if verbose {
-		fmt.Printf("  Product ID: %s\n", resp.ProductID)
-		fmt.Printf("  Version ID: %s\n", resp.VersionID)
+	if verbose && resp.Replayed {
+		fmt.Fprintf(os.Stderr, "ℹ API reported this request as a replay - the event was already recorded by an earlier attempt\n")
 	}
 
-	// Write GitHub Actions job summary
-	uiURL := viper.GetString("ui_url")
-	github.WriteSuccessSummary("Build", "", statusValue, version, event.SCMSha, uiURL, resp.VersionID)
+	if resp.Status == api.StatusQueued {
+		// Queued for later delivery (API unreachable with --fail-on-api-error=false)
+		fmt.Fprintf(os.Stderr, "⚠ Build event queued for later delivery\n")
+	} else {
+		fmt.Fprintf(os.Stderr, "✓ Build event tracked successfully\n")
+		fmt.Fprintf(os.Stderr, "  Event ID: %s\n", resp.ID)
+		if verbose {
+			fmt.Fprintf(os.Stderr, "  Product ID: %s\n", resp.ProductID)
+			fmt.Fprintf(os.Stderr, "  Version ID: %s\n", resp.VersionID)
+		}
 
-	return nil
+		ciReporter().Success(report.Success{
+			Action:     "Build",
+			Status:     statusValue,
+			Version:    version,
+			SCMSha:     event.SCMSha,
+			UIURL:      viper.GetString("ui_url"),
+			ResourceID: resp.VersionID,
+		})
+	}
+
+	return reportResult(&eventResult{Event: event, Response: resp})
+}
+
+// reportBuildError displays a failed build event submission and returns the exit code for it
+func reportBuildError(err error) int {
+	// Cancelled by SIGINT/SIGTERM
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "Cancelled: %s\n", err.Error())
+		return exitCodeCancelled
+	}
+	if apiErr, ok := err.(*api.APIError); ok {
+		// API error - exit code 2
+		ciReporter().APIError(report.Failure{Action: "Build", Message: apiErr.Error()})
+		fmt.Fprintf(os.Stderr, "API error: %s\n", apiErr.Error())
+		return 2
+	}
+	// Network or other error - exit code 2
+	ciReporter().NetworkError(report.Failure{Action: "Build", Message: err.Error()})
+	fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+	return 2
 }
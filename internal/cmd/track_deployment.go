@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -11,6 +13,7 @@ import (
 	"github.com/versioner-io/versioner-cli/internal/api"
 	"github.com/versioner-io/versioner-cli/internal/cicd"
 	"github.com/versioner-io/versioner-cli/internal/github"
+	"github.com/versioner-io/versioner-cli/internal/report"
 	"github.com/versioner-io/versioner-cli/internal/status"
 )
 
@@ -29,7 +32,9 @@ Exit codes:
   0 - Success
   1 - General error (network, invalid arguments)
   4 - API error (validation, authentication)
-  5 - Preflight check failure (deployment blocked)`,
+  5 - Preflight check failure (deployment blocked, or still blocked when
+      --max-wait elapsed with --wait-for-preflight)
+  130 - Cancelled (SIGINT/SIGTERM)`,
 	Example: `  # Track a deployment start (triggers preflight checks)
   versioner track deployment \
     --product=api-service \
@@ -51,37 +56,26 @@ Exit codes:
     --version=1.2.3-hotfix \
     --status=started \
     --skip-preflight-checks`,
-	RunE: runDeploymentTrack,
+	PreRunE: loadProjectConfig,
+	RunE:    runDeploymentTrack,
 }
 
 func init() {
 	trackCmd.AddCommand(deploymentCmd)
 
 	// Required flags
-	deploymentCmd.Flags().String("product", "", "Product/application name (required)")
-	deploymentCmd.Flags().String("environment", "", "Environment name (required)")
-	deploymentCmd.Flags().String("version", "", "Version string (required)")
+	addDeploymentEventFlags(deploymentCmd)
 	deploymentCmd.Flags().String("status", "success", "Deployment status (pending, started, completed, failed, aborted)")
 
 	// Optional flags
-	deploymentCmd.Flags().String("source-system", "", "Source system (github, jenkins, gitlab, etc.)")
-	deploymentCmd.Flags().String("build-number", "", "Build number from CI system")
-	deploymentCmd.Flags().String("scm-sha", "", "Git commit SHA (40-character hash)")
-	deploymentCmd.Flags().String("scm-repository", "", "Source control repository (e.g., owner/repo)")
-	deploymentCmd.Flags().String("deploy-url", "", "Link to deployment run/logs")
-	deploymentCmd.Flags().String("invoke-id", "", "Invocation/run ID from CI system")
-	deploymentCmd.Flags().String("deployed-by", "", "User identifier (username, email, or ID)")
-	deploymentCmd.Flags().String("deployed-by-email", "", "User email")
-	deploymentCmd.Flags().String("deployed-by-name", "", "User display name")
 	deploymentCmd.Flags().String("completed-at", "", "Deployment completion timestamp (ISO 8601 format)")
-	deploymentCmd.Flags().String("extra-metadata", "", "Additional metadata as JSON object (max 100KB)")
-	deploymentCmd.Flags().Bool("fail-on-api-error", true, "Fail command if API is unreachable or returns auth/validation errors (default: true)")
-	deploymentCmd.Flags().Bool("skip-preflight-checks", false, "Skip preflight checks (emergency use only)")
+	deploymentCmd.Flags().Bool("github-pr-comment", false, "Comment on the commit's pull requests where it has been deployed, on successful deployment events (GitHub Actions, requires GITHUB_TOKEN)")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("product", deploymentCmd.Flags().Lookup("product"))
 	_ = viper.BindPFlag("environment", deploymentCmd.Flags().Lookup("environment"))
 	_ = viper.BindPFlag("version", deploymentCmd.Flags().Lookup("version"))
+	_ = viper.BindPFlag("version_from", deploymentCmd.Flags().Lookup("version-from"))
 	_ = viper.BindPFlag("status", deploymentCmd.Flags().Lookup("status"))
 	_ = viper.BindPFlag("source_system", deploymentCmd.Flags().Lookup("source-system"))
 	_ = viper.BindPFlag("build_number", deploymentCmd.Flags().Lookup("build-number"))
@@ -93,72 +87,239 @@ func init() {
 	_ = viper.BindPFlag("deployed_by_email", deploymentCmd.Flags().Lookup("deployed-by-email"))
 	_ = viper.BindPFlag("deployed_by_name", deploymentCmd.Flags().Lookup("deployed-by-name"))
 	_ = viper.BindPFlag("fail_on_api_error", deploymentCmd.Flags().Lookup("fail-on-api-error"))
+	_ = viper.BindPFlag("github_pr_comment", deploymentCmd.Flags().Lookup("github-pr-comment"))
+}
+
+// addDeploymentEventFlags registers the flags shared by every command that records deployment events
+func addDeploymentEventFlags(c *cobra.Command) {
+	addDeploymentFlags(c)
+	addDeploymentSubmitFlags(c)
+}
+
+// addDeploymentFlags registers the flags describing a deployment, which are read by
+// buildDeploymentEvent
+func addDeploymentFlags(c *cobra.Command) {
+	// Required flags
+	c.Flags().String("product", "", "Product/application name (required)")
+	c.Flags().String("environment", "", "Environment name (required)")
+	c.Flags().String("version", "", "Version string (required)")
+	addVersionFromFlag(c)
+
+	// Optional flags
+	c.Flags().String("build-number", "", "Build number from CI system")
+	c.Flags().String("scm-sha", "", "Git commit SHA (40-character hash)")
+	c.Flags().String("scm-repository", "", "Source control repository (e.g., owner/repo)")
+	addDeploymentContextFlags(c)
+}
+
+// addDeploymentContextFlags registers the flags describing how and by whom a deployment
+// is made, as opposed to what is deployed
+func addDeploymentContextFlags(c *cobra.Command) {
+	c.Flags().String("source-system", "", "Source system (github, jenkins, gitlab, etc.)")
+	c.Flags().String("deploy-url", "", "Link to deployment run/logs")
+	c.Flags().String("invoke-id", "", "Invocation/run ID from CI system")
+	c.Flags().String("deployed-by", "", "User identifier (username, email, or ID)")
+	c.Flags().String("deployed-by-email", "", "User email")
+	c.Flags().String("deployed-by-name", "", "User display name")
+	c.Flags().String("extra-metadata", "", "Additional metadata as JSON object (max 100KB)")
+}
+
+// addDeploymentSubmitFlags registers the flags controlling how a deployment event is
+// recorded, which are read by applySubmitFlags and when the event is sent
+func addDeploymentSubmitFlags(c *cobra.Command) {
+	c.Flags().Bool("fail-on-api-error", true, "Fail command if API is unreachable or returns auth/validation errors (default: true)")
+	c.Flags().Bool("skip-preflight-checks", false, "Skip preflight checks (emergency use only)")
+	c.Flags().String("idempotency-key", "", "Idempotency key for the event (default: derived from product, version, environment, status and CI run)")
+	c.Flags().Bool("wait-for-preflight", false, "Wait for blocking preflight checks (schedules, soak time, approvals) to pass instead of failing")
+	addWaitFlags(c)
+	c.Flags().Bool("github-deployments", false, "Also create GitHub Deployments and deployment statuses (GitHub Actions, requires GITHUB_TOKEN)")
 }
 
 func runDeploymentTrack(cmd *cobra.Command, args []string) error {
 	// Auto-detect CI/CD environment
 	detected := cicd.Detect()
 
-	// Get required fields (with auto-detection fallback)
-	product, _ := cmd.Flags().GetString("product")
-	if product == "" {
-		product = viper.GetString("product")
+	statusValue, _ := cmd.Flags().GetString("status")
+
+	event, err := buildDeploymentEvent(cmd, detected, statusValue)
+	if err != nil {
+		return err
 	}
-	if product == "" {
-		product = detected.Product
+	applySubmitFlags(cmd, detected, event)
+
+	// Parse timestamp if provided
+	if completedAtStr := cmd.Flags().Lookup("completed-at").Value.String(); completedAtStr != "" {
+		completedAt, err := time.Parse(time.RFC3339, completedAtStr)
+		if err != nil {
+			return fmt.Errorf("invalid completed-at timestamp: %w", err)
+		}
+		event.CompletedAt = &completedAt
 	}
 
-	environment, _ := cmd.Flags().GetString("environment")
-	if environment == "" {
-		environment = viper.GetString("environment")
+	// Create API client
+	client, err := newAPIClient(cmd)
+	if err != nil {
+		return err
 	}
 
-	version, _ := cmd.Flags().GetString("version")
-	if version == "" {
-		version = viper.GetString("version")
+	wait, err := deploymentWaitOptions(cmd, event)
+	if err != nil {
+		return err
 	}
-	if version == "" {
-		version = detected.Version
+
+	if verbose {
+		printDeploymentEvent(event, detected, client.BaseURL)
 	}
 
-	statusValue, _ := cmd.Flags().GetString("status")
+	// Send the event
+	resp := submitDeploymentEvent(cmd, client, event, wait)
 
-	// Normalize and validate status
-	canonicalStatus, wasNormalized := status.Normalize(statusValue)
-	if verbose && wasNormalized {
-		fmt.Fprintf(os.Stderr, "ℹ Status '%s' will be normalized to '%s' by the API\n", statusValue, canonicalStatus)
+	if verbose && resp.Replayed {
+		fmt.Fprintf(os.Stderr, "ℹ API reported this request as a replay - the event was already recorded by an earlier attempt\n")
+	}
+	handlePreflightWarnings(resp.Warnings)
+
+	if resp.Status == api.StatusQueued {
+		// Queued for later delivery (API unreachable with --fail-on-api-error=false)
+		fmt.Fprintf(os.Stderr, "⚠ Deployment event queued for later delivery\n")
+	} else {
+		fmt.Fprintf(os.Stderr, "✓ Deployment event tracked successfully\n")
+		fmt.Fprintf(os.Stderr, "  Event ID: %s\n", resp.ID)
+		if verbose {
+			fmt.Fprintf(os.Stderr, "  Product ID: %s\n", resp.ProductID)
+			fmt.Fprintf(os.Stderr, "  Version ID: %s\n", resp.VersionID)
+			fmt.Fprintf(os.Stderr, "  Environment ID: %s\n", resp.EnvironmentID)
+		}
+
+		uiURL := viper.GetString("ui_url")
+		ciReporter().Success(report.Success{
+			Action:      "Deployment",
+			Environment: event.EnvironmentName,
+			Status:      statusValue,
+			Version:     event.Version,
+			SCMSha:      event.SCMSha,
+			UIURL:       uiURL,
+			ResourceID:  resp.ID,
+		})
+
+		// Only a successful deployment is worth announcing on the pull requests
+		if viper.GetBool("github_pr_comment") && status.GetCanonical(statusValue) == status.Completed {
+			commentOnPullRequests(cmd.Context(), client, event, resp.ID, uiURL)
+		}
+	}
+
+	return reportResult(newDeploymentResult(event, resp, nil))
+}
+
+// mirrorGitHubDeployment mirrors a recorded deployment event as a GitHub Deployment if
+// --github-deployments (or the github_deployments setting) is enabled
+func mirrorGitHubDeployment(ctx context.Context, cmd *cobra.Command, event *api.DeploymentEventCreate, resp *api.DeploymentResponse) {
+	if resp.Status == api.StatusQueued || !boolSetting(cmd, "github-deployments", "github_deployments") {
+		return
+	}
+	reportGitHubDeployment(ctx, event, report.ViewURL(viper.GetString("ui_url"), "Deployment", resp.ID))
+}
+
+// boolSetting returns a boolean flag of cmd if it was given, or else the viper config value.
+// Flags registered on several commands can't all be bound to one viper key.
+func boolSetting(cmd *cobra.Command, flagName, key string) bool {
+	if flag := cmd.Flags().Lookup(flagName); flag != nil && flag.Changed {
+		value, _ := cmd.Flags().GetBool(flagName)
+		return value
+	}
+	return viper.GetBool(key)
+}
+
+// reportGitHubDeployment mirrors a tracked deployment event as a GitHub Deployment.
+// Failures are reported as warnings - the event itself has already been recorded.
+func reportGitHubDeployment(ctx context.Context, event *api.DeploymentEventCreate, viewURL string) {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "ℹ Not running in GitHub Actions - skipping GitHub deployment\n")
+		}
+		return
+	}
+
+	client := github.NewClientFromEnv()
+	if client == nil {
+		fmt.Fprintf(os.Stderr, "⚠ GITHUB_TOKEN is not set - skipping GitHub deployment\n")
+		return
+	}
+
+	id, err := client.ReportDeployment(ctx, github.DeploymentUpdate{
+		SHA:         event.SCMSha,
+		Environment: event.EnvironmentName,
+		Version:     event.Version,
+		Status:      event.Status,
+		ViewURL:     viewURL,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Failed to update GitHub deployment: %s\n", err.Error())
+		return
+	}
+	if verbose {
+		fmt.Fprintf(os.Stderr, "  GitHub Deployment ID: %d\n", id)
+	}
+}
+
+// buildDeploymentEvent assembles a deployment event from command flags, viper config and
+// auto-detected CI/CD values (in that order of precedence)
+func buildDeploymentEvent(cmd *cobra.Command, detected *cicd.DetectedValues, statusValue string) (*api.DeploymentEventCreate, error) {
+	// Get required fields (with auto-detection fallback)
+	product := resolveProduct(cmd, detected)
+
+	environment, _ := cmd.Flags().GetString("environment")
+	if environment == "" {
+		environment = viper.GetString("environment")
+	}
+
+	version, err := resolveVersion(cmd, detected, product)
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate required fields
 	if product == "" {
-		return fmt.Errorf("--product is required")
+		return nil, fmt.Errorf("--product is required")
 	}
 	if environment == "" {
-		return fmt.Errorf("--environment is required")
+		return nil, fmt.Errorf("--environment is required")
 	}
 	if version == "" {
-		return fmt.Errorf("--version is required")
+		return nil, fmt.Errorf("--version is required")
 	}
 
-	// Get API configuration
-	apiURL := viper.GetString("api_url")
-	apiKey := viper.GetString("api_key")
+	return newDeploymentEvent(cmd, detected, product, environment, version, statusValue)
+}
 
-	if apiKey == "" {
-		return fmt.Errorf("API key is required. Set VERSIONER_API_KEY environment variable or use --api-key flag")
+// resolveProduct returns the product from the --product flag, viper config, the project
+// config (the product whose paths contain the working directory) or auto-detection
+func resolveProduct(cmd *cobra.Command, detected *cicd.DetectedValues) string {
+	product, _ := cmd.Flags().GetString("product")
+	if product == "" {
+		product = viper.GetString("product")
 	}
-
-	// Get fail-on-api-error flag (default: true)
-	failOnApiError, _ := cmd.Flags().GetBool("fail-on-api-error")
-	if !cmd.Flags().Changed("fail-on-api-error") {
-		failOnApiError = viper.GetBool("fail_on_api_error")
-		if !viper.IsSet("fail_on_api_error") {
-			failOnApiError = true
+	if product == "" {
+		product = projectProduct()
+		if product != "" && verbose {
+			fmt.Fprintf(os.Stderr, "ℹ Using product %s from %s\n", product, project.Path)
 		}
 	}
+	if product == "" {
+		product = detected.Product
+	}
+	return product
+}
 
-	// Create API client
-	client := api.NewClient(apiURL, apiKey, debug, failOnApiError)
+// newDeploymentEvent assembles a deployment event of version of product to environment,
+// filling in the remaining fields from command flags, viper config and auto-detected
+// CI/CD values (in that order of precedence)
+func newDeploymentEvent(cmd *cobra.Command, detected *cicd.DetectedValues, product, environment, version, statusValue string) (*api.DeploymentEventCreate, error) {
+	// Normalize and validate status
+	canonicalStatus, wasNormalized := status.Normalize(statusValue)
+	if verbose && wasNormalized {
+		fmt.Fprintf(os.Stderr, "ℹ Status '%s' will be normalized to '%s' by the API\n", statusValue, canonicalStatus)
+	}
 
 	// Helper function to get value with fallback (cmd flags -> viper -> auto-detected)
 	getWithFallback := func(flagName string, viperKey string, fallback string) string {
@@ -191,15 +352,6 @@ func runDeploymentTrack(cmd *cobra.Command, args []string) error {
 		DeployedByName:  getWithFallback("deployed-by-name", "deployed_by_name", detected.BuiltByName),
 	}
 
-	// Parse timestamp if provided
-	if completedAtStr := cmd.Flags().Lookup("completed-at").Value.String(); completedAtStr != "" {
-		completedAt, err := time.Parse(time.RFC3339, completedAtStr)
-		if err != nil {
-			return fmt.Errorf("invalid completed-at timestamp: %w", err)
-		}
-		event.CompletedAt = &completedAt
-	}
-
 	// Get auto-detected metadata from CI/CD system
 	autoMetadata := detected.ExtraMetadata()
 
@@ -209,14 +361,22 @@ func runDeploymentTrack(cmd *cobra.Command, args []string) error {
 		var err error
 		userMetadata, err = ParseExtraMetadata(extraMetadataStr)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	// Merge metadata (user values take precedence)
-	event.ExtraMetadata = MergeMetadata(autoMetadata, userMetadata)
+	// Merge metadata (project config values override auto-detected ones, and user values
+	// take precedence over both)
+	event.ExtraMetadata = MergeMetadata(MergeMetadata(autoMetadata, project.Metadata(product, environment)), userMetadata)
 
-	// Get skip-preflight-checks flag
+	return event, nil
+}
+
+// applySubmitFlags sets the options of a deployment event that is about to be recorded
+// from the flags registered by addDeploymentSubmitFlags: --skip-preflight-checks and
+// the idempotency key. Commands that only evaluate a deployment (preflight, wait) don't
+// call it.
+func applySubmitFlags(cmd *cobra.Command, detected *cicd.DetectedValues, event *api.DeploymentEventCreate) {
 	skipPreflightChecks, _ := cmd.Flags().GetBool("skip-preflight-checks")
 	if skipPreflightChecks {
 		fmt.Fprintf(os.Stderr, "⚠️  DEPRECATION WARNING: --skip-preflight-checks is deprecated\n")
@@ -226,62 +386,124 @@ func runDeploymentTrack(cmd *cobra.Command, args []string) error {
 	}
 	event.SkipPreflightChecks = skipPreflightChecks
 
-	if verbose {
-		fmt.Fprintf(os.Stderr, "Tracking deployment event:\n")
-		if detected.System != cicd.SystemUnknown {
-			fmt.Fprintf(os.Stderr, "  ℹ Auto-detected CI system: %s\n", detected.System)
-		}
-		fmt.Fprintf(os.Stderr, "  Product: %s\n", product)
-		fmt.Fprintf(os.Stderr, "  Environment: %s\n", environment)
-		fmt.Fprintf(os.Stderr, "  Version: %s\n", version)
-		fmt.Fprintf(os.Stderr, "  Status: %s\n", statusValue)
-		if event.SourceSystem != "" {
-			fmt.Fprintf(os.Stderr, "  Source System: %s\n", event.SourceSystem)
-		}
-		if event.SCMRepository != "" {
-			fmt.Fprintf(os.Stderr, "  Repository: %s\n", event.SCMRepository)
-		}
-		if event.SCMSha != "" {
-			fmt.Fprintf(os.Stderr, "  Commit SHA: %s\n", event.SCMSha)
-		}
-		fmt.Fprintf(os.Stderr, "  API URL: %s\n", apiURL)
-		fmt.Fprintf(os.Stderr, "\n")
+	event.IdempotencyKey = deploymentIdempotencyKey(cmd, detected, event)
+}
+
+// deploymentIdempotencyKey returns the --idempotency-key flag value, or a key derived from the event
+func deploymentIdempotencyKey(cmd *cobra.Command, detected *cicd.DetectedValues, event *api.DeploymentEventCreate) string {
+	if key, _ := cmd.Flags().GetString("idempotency-key"); key != "" {
+		return key
 	}
+	return deriveIdempotencyKey("deployment", event.ProductName, event.Version, event.EnvironmentName,
+		status.GetCanonical(event.Status), event.InvokeID, detected.RetryID())
+}
 
-	// Send the event
-	resp, err := client.CreateDeploymentEvent(event)
-	if err != nil {
-		if apiErr, ok := err.(*api.APIError); ok {
-			// Check if this is a preflight check failure
-			if apiErr.IsPreflightError() {
-				handlePreflightError(apiErr)
-				os.Exit(5) // Exit code 5 for preflight failures
-			}
-			// Other API error - exit code 4
-			github.WriteGenericErrorAnnotation("Deployment", "API Error", apiErr.Error())
-			fmt.Fprintf(os.Stderr, "API error: %s\n", apiErr.Error())
-			os.Exit(4)
-		}
-		// Network or other error - exit code 1
-		github.WriteGenericErrorAnnotation("Deployment", "Network Error", err.Error())
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
-		os.Exit(1)
+// printDeploymentEvent writes a human-readable description of a deployment event to stderr
+func printDeploymentEvent(event *api.DeploymentEventCreate, detected *cicd.DetectedValues, apiURL string) {
+	reporter := ciReporter()
+	reporter.StartSection("versioner_deployment_event", "Tracking deployment event")
+	defer reporter.EndSection("versioner_deployment_event")
+
+	fmt.Fprintf(os.Stderr, "Tracking deployment event:\n")
+	if detected.System != cicd.SystemUnknown {
+		fmt.Fprintf(os.Stderr, "  ℹ Auto-detected CI system: %s\n", detected.System)
+	}
+	fmt.Fprintf(os.Stderr, "  Product: %s\n", event.ProductName)
+	fmt.Fprintf(os.Stderr, "  Environment: %s\n", event.EnvironmentName)
+	fmt.Fprintf(os.Stderr, "  Version: %s\n", event.Version)
+	fmt.Fprintf(os.Stderr, "  Status: %s\n", event.Status)
+	if event.SourceSystem != "" {
+		fmt.Fprintf(os.Stderr, "  Source System: %s\n", event.SourceSystem)
+	}
+	if event.SCMRepository != "" {
+		fmt.Fprintf(os.Stderr, "  Repository: %s\n", event.SCMRepository)
+	}
+	if event.SCMSha != "" {
+		fmt.Fprintf(os.Stderr, "  Commit SHA: %s\n", event.SCMSha)
 	}
+	if event.IdempotencyKey != "" {
+		fmt.Fprintf(os.Stderr, "  Idempotency Key: %s\n", event.IdempotencyKey)
+	}
+	fmt.Fprintf(os.Stderr, "  API URL: %s\n", apiURL)
+	fmt.Fprintf(os.Stderr, "\n")
+}
 
-	// Success
-	fmt.Printf("✓ Deployment event tracked successfully\n")
-	fmt.Printf("  Event ID: %s\n", resp.ID)
-	if verbose {
-		fmt.Printf("  Product ID: %s\n", resp.ProductID)
-		fmt.Printf("  Version ID: %s\n", resp.VersionID)
-		fmt.Printf("  Environment ID: %s\n", resp.EnvironmentID)
+// deploymentWaitOptions returns the wait options for --wait-for-preflight, or nil when not waiting
+func deploymentWaitOptions(cmd *cobra.Command, event *api.DeploymentEventCreate) (*waitOptions, error) {
+	waitForPreflight, _ := cmd.Flags().GetBool("wait-for-preflight")
+	if !waitForPreflight {
+		return nil, nil
+	}
+
+	// Preflight checks only run for 'started' events
+	if status.GetCanonical(event.Status) != status.Started {
+		return nil, fmt.Errorf("--wait-for-preflight requires --status=%s", status.Started)
 	}
 
-	// Write GitHub Actions job summary
-	uiURL := viper.GetString("ui_url")
-	github.WriteSuccessSummary("Deployment", environment, statusValue, version, event.SCMSha, uiURL, resp.ID)
+	return waitOptionsFromFlags(cmd)
+}
+
+// submitDeploymentEvent sends a deployment event and exits the process with the
+// documented exit code if the API rejects it or cannot be reached. With wait set,
+// preflight rejections that resolve with time are waited out first. Recorded events are
+// mirrored as GitHub Deployments with --github-deployments.
+func submitDeploymentEvent(cmd *cobra.Command, client *api.Client, event *api.DeploymentEventCreate, wait *waitOptions) *api.DeploymentResponse {
+	ctx := cmd.Context()
+	var resp *api.DeploymentResponse
+	submit := func() error {
+		var err error
+		resp, err = client.CreateDeploymentEventWithContext(ctx, event)
+		return err
+	}
+
+	var err error
+	if wait != nil {
+		check := func() error {
+			_, err := client.CheckPreflight(ctx, event)
+			return err
+		}
+		err = wait.wait(ctx, submit, check)
+	} else {
+		err = submit()
+	}
+	if err != nil {
+		exitDeploymentError(event, err)
+	}
 
-	return nil
+	mirrorGitHubDeployment(ctx, cmd, event, resp)
+	return resp
+}
+
+// exitDeploymentError reports a failed deployment event submission or preflight check,
+// including the structured result for --output, and exits with the documented exit code
+func exitDeploymentError(event *api.DeploymentEventCreate, err error) {
+	code := reportDeploymentError(err)
+	_ = reportResult(newDeploymentResult(event, nil, err))
+	os.Exit(code)
+}
+
+// reportDeploymentError displays a failed deployment event submission and returns the exit code for it
+func reportDeploymentError(err error) int {
+	// Cancelled by SIGINT/SIGTERM - nothing to annotate
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "Cancelled: %s\n", err.Error())
+		return exitCodeCancelled
+	}
+	if apiErr, ok := err.(*api.APIError); ok {
+		// Check if this is a preflight check failure
+		if apiErr.IsPreflightError() {
+			handlePreflightError(apiErr)
+			return 5 // Exit code 5 for preflight failures
+		}
+		// Other API error - exit code 4
+		ciReporter().APIError(report.Failure{Action: "Deployment", Message: apiErr.Error()})
+		fmt.Fprintf(os.Stderr, "API error: %s\n", apiErr.Error())
+		return 4
+	}
+	// Network or other error - exit code 1
+	ciReporter().NetworkError(report.Failure{Action: "Deployment", Message: err.Error()})
+	fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+	return 1
 }
 
 // handlePreflightError formats and displays preflight check errors
@@ -302,8 +524,15 @@ func handlePreflightError(apiErr *api.APIError) {
 		}
 	}
 
-	// Write GitHub Actions annotation if running in GitHub Actions
-	github.WriteErrorAnnotation(apiErr.StatusCode, code, message, ruleName, retryAfter, details)
+	// Surface the rejection in the CI system's UI (annotations, job summary, ...)
+	ciReporter().PreflightRejection(report.Rejection{
+		StatusCode: apiErr.StatusCode,
+		Code:       code,
+		Message:    message,
+		RuleName:   ruleName,
+		RetryAfter: retryAfter,
+		Details:    details,
+	})
 
 	// Format output based on status code and error code
 	switch apiErr.StatusCode {
@@ -372,3 +601,33 @@ func handlePreflightError(apiErr *api.APIError) {
 		}
 	}
 }
+
+// handlePreflightWarnings displays report_only rules that failed without blocking the deployment
+func handlePreflightWarnings(warnings []api.PreflightWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	reporter := ciReporter()
+	fmt.Fprintf(os.Stderr, "⚠️  Deployment rule warnings (report only, not blocking):\n")
+	for _, w := range warnings {
+		title := w.Code
+		if w.RuleName != "" {
+			title = w.RuleName
+			if w.Code != "" {
+				title = fmt.Sprintf("%s (%s)", w.RuleName, w.Code)
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "  - %s\n", title)
+		if w.Message != "" {
+			fmt.Fprintf(os.Stderr, "    %s\n", w.Message)
+		}
+		if w.RetryAfter != "" {
+			fmt.Fprintf(os.Stderr, "    Retry after: %s\n", w.RetryAfter)
+		}
+
+		reporter.Warning("Deployment Rule Warning: "+title, w.Message)
+	}
+	fmt.Fprintf(os.Stderr, "\n")
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestDeploymentCommands_RegisterGitHubDeployments(t *testing.T) {
+	for _, path := range [][]string{{"track", "deployment"}, {"exec"}, {"promote"}, {"rollback"}} {
+		c, _, err := rootCmd.Find(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Flags().Lookup("github-deployments") == nil {
+			t.Errorf("%s does not register --github-deployments", c.CommandPath())
+		}
+	}
+}
+
+func TestBoolSetting(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	newCommand := func() *cobra.Command {
+		c := &cobra.Command{}
+		c.Flags().Bool("github-deployments", false, "")
+		return c
+	}
+
+	if boolSetting(newCommand(), "github-deployments", "github_deployments") {
+		t.Error("Expected false by default")
+	}
+
+	viper.Set("github_deployments", true)
+	if !boolSetting(newCommand(), "github-deployments", "github_deployments") {
+		t.Error("Expected the config value without the flag")
+	}
+
+	c := newCommand()
+	_ = c.Flags().Set("github-deployments", "false")
+	if boolSetting(c, "github-deployments", "github_deployments") {
+		t.Error("Expected the flag to override the config value")
+	}
+}
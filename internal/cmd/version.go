@@ -11,13 +11,37 @@ var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
 	Long:  "Display the version, git commit, and build date of the Versioner CLI",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if structuredOutput() {
+			return writeResult(&versionInfo{
+				Version:   version.Version,
+				Commit:    version.Commit,
+				BuildDate: version.BuildDate,
+			})
+		}
+
 		fmt.Printf("versioner version %s\n", version.Version)
 		fmt.Printf("  git commit: %s\n", version.Commit)
 		fmt.Printf("  build date: %s\n", version.BuildDate)
+		return nil
 	},
 }
 
+// versionInfo is the structured output of 'version'
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+}
+
+func (v *versionInfo) envVars() []envVar {
+	return []envVar{
+		{"VERSIONER_CLI_VERSION", v.Version},
+		{"VERSIONER_CLI_COMMIT", v.Commit},
+		{"VERSIONER_CLI_BUILD_DATE", v.BuildDate},
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(versionCmd)
 }
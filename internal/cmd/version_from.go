@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/versionsource"
+)
+
+// addVersionFromFlag registers --version-from on commands that take --version
+func addVersionFromFlag(c *cobra.Command) {
+	c.Flags().String("version-from", "", "Comma-separated sources to derive the version from when --version is not set (git-tag, git-describe, package.json, pyproject.toml, Cargo.toml, pom.xml, Chart.yaml, VERSION)")
+}
+
+// resolveVersion returns the version from the --version flag or viper config, or else
+// the first version found by the --version-from sources (or the product's version_from
+// in the project config), falling back to the auto-detected CI/CD value. No sources are
+// tried unless some are configured. Products declared in the project config are
+// resolved in their directory.
+func resolveVersion(cmd *cobra.Command, detected *cicd.DetectedValues, product string) (string, error) {
+	version, _ := cmd.Flags().GetString("version")
+	if version == "" {
+		version = viper.GetString("version")
+	}
+	if version != "" {
+		return version, nil
+	}
+
+	fromFlag, _ := cmd.Flags().GetString("version-from")
+	sources := versionsource.ParseSources([]string{fromFlag})
+	if len(sources) == 0 {
+		sources = versionsource.ParseSources(viper.GetStringSlice("version_from"))
+	}
+	if p := project.Product(product); len(sources) == 0 && p != nil {
+		sources = p.VersionFrom
+	}
+
+	// Without configured sources the CI/CD value is used as is
+	if len(sources) > 0 {
+		result, err := resolveVersionFrom(project.ProductDir(product), sources)
+		if err != nil {
+			return "", err
+		}
+		if result != nil {
+			return result.Version, nil
+		}
+	}
+
+	if verbose && detected.Version != "" {
+		fmt.Fprintf(os.Stderr, "ℹ Using version %s auto-detected from %s\n", detected.Version, detected.System)
+	}
+	return detected.Version, nil
+}
+
+// resolveVersionFrom returns the first version found by sources in dir (the working
+// directory if empty), or nil if none has one
+func resolveVersionFrom(dir string, sources []string) (*versionsource.Result, error) {
+	if dir == "" {
+		var err error
+		if dir, err = os.Getwd(); err != nil {
+			return nil, err
+		}
+	}
+	result, attempts, err := versionsource.NewResolver(dir).Resolve(sources)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --version-from: %w", err)
+	}
+
+	if verbose {
+		for _, a := range attempts {
+			fmt.Fprintf(os.Stderr, "  ℹ Version source %s: %s\n", a.Source, a.Reason)
+		}
+		if result != nil {
+			fmt.Fprintf(os.Stderr, "ℹ Using version %s from %s (%s)\n", result.Version, result.Source, result.Detail)
+		}
+	}
+	return result, nil
+}
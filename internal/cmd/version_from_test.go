@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+)
+
+func newVersionCommand() *cobra.Command {
+	c := &cobra.Command{}
+	c.Flags().String("version", "", "")
+	addVersionFromFlag(c)
+	return c
+}
+
+func TestResolveVersion(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte("3.1.4\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	detected := &cicd.DetectedValues{System: cicd.SystemGitHub, Version: "abc123de"}
+
+	// Explicit version wins
+	c := newVersionCommand()
+	_ = c.Flags().Set("version", "1.0.0")
+	if version, err := resolveVersion(c, detected, ""); err != nil || version != "1.0.0" {
+		t.Errorf("Expected 1.0.0, got %q (%v)", version, err)
+	}
+
+	// Without configured sources, the detected version is kept even if the project has a version file
+	if version, err := resolveVersion(newVersionCommand(), detected, ""); err != nil || version != "abc123de" {
+		t.Errorf("Expected abc123de, got %q (%v)", version, err)
+	}
+
+	// Configured sources are read from the project
+	c = newVersionCommand()
+	_ = c.Flags().Set("version-from", "package.json,VERSION")
+	if version, err := resolveVersion(c, detected, ""); err != nil || version != "3.1.4" {
+		t.Errorf("Expected 3.1.4, got %q (%v)", version, err)
+	}
+
+	t.Cleanup(viper.Reset)
+	viper.Set("version_from", "VERSION")
+	if version, err := resolveVersion(newVersionCommand(), detected, ""); err != nil || version != "3.1.4" {
+		t.Errorf("Expected 3.1.4 from config, got %q (%v)", version, err)
+	}
+
+	// Falling back to the detected version when the selected sources have none
+	c = newVersionCommand()
+	_ = c.Flags().Set("version-from", "package.json")
+	if version, err := resolveVersion(c, detected, ""); err != nil || version != "abc123de" {
+		t.Errorf("Expected abc123de, got %q (%v)", version, err)
+	}
+
+	c = newVersionCommand()
+	_ = c.Flags().Set("version-from", "setup.py")
+	if _, err := resolveVersion(c, detected, ""); err == nil {
+		t.Error("Expected an error for an unknown version source")
+	}
+}
+
+func TestResolveVersion_ProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".versioner.yaml":                "products:\n  web:\n    paths: [apps/web]\n    version_from: [package.json]\n  api:\n    paths: [services/api]\n",
+		"apps/web/package.json":          `{"name": "web", "version": "2.0.0"}`,
+		"apps/web/VERSION":               "9.9.9\n",
+		"services/api/VERSION":           "1.4.0\n",
+		"services/api/internal/.gitkeep": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(filepath.Join(root, "services", "api", "internal"))
+	if err := loadProject(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { project = nil })
+	detected := &cicd.DetectedValues{System: cicd.SystemGitHub, Version: "abc123de"}
+
+	// The working directory selects the product, which has no version sources
+	c := newVersionCommand()
+	c.Flags().String("product", "", "")
+	product := resolveProduct(c, detected)
+	if product != "api" {
+		t.Fatalf("Expected product api, got %q", product)
+	}
+	if version, err := resolveVersion(c, detected, product); err != nil || version != "abc123de" {
+		t.Errorf("Expected abc123de, got %q (%v)", version, err)
+	}
+
+	// Sources given for it are read from its directory, which holds the version file
+	c = newVersionCommand()
+	_ = c.Flags().Set("version-from", "VERSION")
+	if version, err := resolveVersion(c, detected, product); err != nil || version != "1.4.0" {
+		t.Errorf("Expected 1.4.0, got %q (%v)", version, err)
+	}
+
+	// Another product is resolved in its own directory, using its version sources
+	if version, err := resolveVersion(newVersionCommand(), detected, "web"); err != nil || version != "2.0.0" {
+		t.Errorf("Expected 2.0.0, got %q (%v)", version, err)
+	}
+
+	// --version-from overrides the product's sources
+	c = newVersionCommand()
+	_ = c.Flags().Set("version-from", "VERSION")
+	if version, err := resolveVersion(c, detected, "web"); err != nil || version != "9.9.9" {
+		t.Errorf("Expected 9.9.9, got %q (%v)", version, err)
+	}
+}
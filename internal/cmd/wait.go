@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/versioner-io/versioner-cli/internal/api"
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+const (
+	defaultMaxWait      = 30 * time.Minute
+	defaultPollInterval = 30 * time.Second
+
+	// progressInterval is how often a progress line is printed during long waits
+	progressInterval = time.Minute
+)
+
+var waitCmd = &cobra.Command{
+	Use:   "wait",
+	Short: "Wait until a deployment would be allowed by preflight checks",
+	Long: `Wait until preflight checks would allow a deployment, without recording it.
+
+Rejections that resolve with time are waited out:
+- No-deploy windows (423) and insufficient soak time wait until the
+  retry_after time returned by the API
+- Approvals (APPROVAL_REQUIRED, QUALITY_APPROVAL_REQUIRED) and concurrent
+  deployments (409) are polled every --poll-interval
+
+Other rejections (e.g. FLOW_VIOLATION) fail immediately.
+
+Use 'track deployment --wait-for-preflight' (or 'exec --wait-for-preflight') to
+wait and then record the 'started' event in one step.
+
+Exit codes:
+  0   - Deployment is allowed
+  1   - General error (network, invalid arguments)
+  4   - API error (validation, authentication)
+  5   - Still blocked when --max-wait elapsed, or blocked by a rule that can't be waited out
+  130 - Cancelled (SIGINT/SIGTERM)`,
+	Example: `  # Wait up to 2 hours for a no-deploy window to end
+  versioner wait \
+    --product=api-service \
+    --environment=production \
+    --version=1.2.3 \
+    --max-wait=2h`,
+	Args:    cobra.NoArgs,
+	PreRunE: loadProjectConfig,
+	RunE:    runWait,
+}
+
+func init() {
+	rootCmd.AddCommand(waitCmd)
+
+	addDeploymentFlags(waitCmd)
+	addWaitFlags(waitCmd)
+}
+
+// addWaitFlags registers the flags that control waiting for preflight checks
+func addWaitFlags(c *cobra.Command) {
+	c.Flags().Duration("max-wait", defaultMaxWait, "Maximum time to wait for preflight checks to pass")
+	c.Flags().Duration("poll-interval", defaultPollInterval, "How often to re-check approvals and concurrent deployments")
+}
+
+// waitOptions controls how long to wait for a blocked deployment to be allowed
+type waitOptions struct {
+	MaxWait      time.Duration
+	PollInterval time.Duration
+
+	// now and sleep are overridable in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// waitOptionsFromFlags reads the wait flags of a command
+func waitOptionsFromFlags(cmd *cobra.Command) (*waitOptions, error) {
+	maxWait, _ := cmd.Flags().GetDuration("max-wait")
+	pollInterval, _ := cmd.Flags().GetDuration("poll-interval")
+
+	if maxWait <= 0 {
+		return nil, fmt.Errorf("--max-wait must be greater than zero")
+	}
+	if pollInterval <= 0 {
+		return nil, fmt.Errorf("--poll-interval must be greater than zero")
+	}
+
+	return &waitOptions{MaxWait: maxWait, PollInterval: pollInterval}, nil
+}
+
+func runWait(cmd *cobra.Command, args []string) error {
+	// Auto-detect CI/CD environment
+	detected := cicd.Detect()
+
+	event, err := buildDeploymentEvent(cmd, detected, status.Started)
+	if err != nil {
+		return err
+	}
+
+	opts, err := waitOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient(cmd)
+	if err != nil {
+		return err
+	}
+	client.FailOnAPIError = true
+	client.Spool = nil
+
+	if verbose {
+		printDeploymentEvent(event, detected, client.BaseURL)
+	}
+
+	var result *api.PreflightResponse
+	check := func() error {
+		var err error
+		result, err = client.CheckPreflight(cmd.Context(), event)
+		return err
+	}
+
+	if err := opts.wait(cmd.Context(), check, nil); err != nil {
+		exitDeploymentError(event, err)
+	}
+
+	handlePreflightWarnings(result.Warnings)
+	fmt.Fprintf(os.Stderr, "✓ Preflight checks passed: %s %s can be deployed to %s\n", event.ProductName, event.Version, event.EnvironmentName)
+
+	return reportResult(newDeploymentResult(event, result, nil))
+}
+
+// wait calls submit until it succeeds, waiting out preflight rejections that resolve
+// with time. While waiting, check is polled (it should evaluate preflight rules
+// without side effects) and submit is only called again once check passes; a nil
+// check polls submit directly. The last error is returned when the rejection can't
+// be waited out or MaxWait elapses.
+func (o *waitOptions) wait(ctx context.Context, submit, check func() error) error {
+	now := o.now
+	if now == nil {
+		now = time.Now
+	}
+	sleep := o.sleep
+	if sleep == nil {
+		sleep = api.SleepContext
+	}
+
+	deadline := now().Add(o.MaxWait)
+	checking := false
+
+	for {
+		var err error
+		if checking && check != nil {
+			// Only resubmit once the checks pass
+			if err = check(); err == nil {
+				err = submit()
+			}
+		} else {
+			err = submit()
+		}
+		if err == nil {
+			return nil
+		}
+
+		apiErr, ok := err.(*api.APIError)
+		if !ok || !apiErr.IsPreflightError() {
+			return err
+		}
+
+		delay, reason, waitable := preflightDelay(apiErr, now(), o.PollInterval)
+		if !waitable {
+			return err
+		}
+
+		remaining := deadline.Sub(now())
+		if delay > remaining {
+			if remaining > 0 {
+				fmt.Fprintf(os.Stderr, "⏱  %s, but that is beyond the maximum wait (%s left)\n\n", reason, remaining.Round(time.Second))
+			} else {
+				fmt.Fprintf(os.Stderr, "⏱  Maximum wait of %s elapsed\n\n", o.MaxWait)
+			}
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "⏳ %s - waiting %s (until %s)\n", reason, delay.Round(time.Second), now().Add(delay).Format("15:04:05"))
+		if err := sleepWithProgress(ctx, sleep, delay, now); err != nil {
+			return err
+		}
+
+		checking = true
+	}
+}
+
+// preflightDelay decides whether a preflight rejection can be waited out and for how long
+func preflightDelay(apiErr *api.APIError, now time.Time, pollInterval time.Duration) (time.Duration, string, bool) {
+	_, _, code, retryAfter, _, _ := apiErr.GetPreflightDetails()
+
+	var reason string
+	switch {
+	case apiErr.StatusCode == 409:
+		return pollInterval, "Another deployment is in progress", true
+	case apiErr.StatusCode == 423:
+		reason = "Deployment blocked by schedule"
+	case code == "INSUFFICIENT_SOAK_TIME":
+		reason = "Soak time not yet met"
+	case code == "APPROVAL_REQUIRED" || code == "QUALITY_APPROVAL_REQUIRED":
+		return pollInterval, "Waiting for approval", true
+	default:
+		return 0, "", false
+	}
+
+	until, ok := parseRetryAfterTime(retryAfter, now)
+	if !ok {
+		return pollInterval, reason, true
+	}
+
+	delay := until.Sub(now)
+	if delay < time.Second {
+		delay = time.Second
+	}
+	return delay, reason, true
+}
+
+// parseRetryAfterTime parses a preflight retry_after value (RFC 3339 timestamp or seconds)
+func parseRetryAfterTime(value string, now time.Time) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	return time.Time{}, false
+}
+
+// sleepWithProgress waits for d, printing a progress line every progressInterval
+func sleepWithProgress(ctx context.Context, sleep func(context.Context, time.Duration) error, d time.Duration, now func() time.Time) error {
+	end := now().Add(d)
+	for {
+		remaining := end.Sub(now())
+		if remaining <= 0 {
+			return nil
+		}
+
+		step := remaining
+		if step > progressInterval {
+			step = progressInterval
+		}
+		if err := sleep(ctx, step); err != nil {
+			return err
+		}
+
+		if left := end.Sub(now()); left > 0 {
+			fmt.Fprintf(os.Stderr, "   … still waiting (%s left)\n", left.Round(time.Second))
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/versioner-io/versioner-cli/internal/api"
+)
+
+func preflightErr(statusCode int, code, retryAfter string) *api.APIError {
+	detail := map[string]interface{}{
+		"error":   "Deployment blocked",
+		"message": "blocked",
+		"code":    code,
+	}
+	if retryAfter != "" {
+		detail["retry_after"] = retryAfter
+	}
+	return &api.APIError{StatusCode: statusCode, Detail: detail}
+}
+
+func TestPreflightDelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	poll := 30 * time.Second
+
+	tests := []struct {
+		name         string
+		err          *api.APIError
+		wantDelay    time.Duration
+		wantWaitable bool
+	}{
+		{"schedule with retry_after", preflightErr(423, "NO_DEPLOY_WINDOW", "2025-01-01T12:10:00Z"), 10 * time.Minute, true},
+		{"schedule without retry_after polls", preflightErr(423, "NO_DEPLOY_WINDOW", ""), poll, true},
+		{"schedule retry_after in the past", preflightErr(423, "NO_DEPLOY_WINDOW", "2025-01-01T11:00:00Z"), time.Second, true},
+		{"soak time in seconds", preflightErr(428, "INSUFFICIENT_SOAK_TIME", "90"), 90 * time.Second, true},
+		{"approval polls", preflightErr(428, "APPROVAL_REQUIRED", ""), poll, true},
+		{"quality approval polls", preflightErr(428, "QUALITY_APPROVAL_REQUIRED", ""), poll, true},
+		{"concurrent deployment polls", preflightErr(409, "DEPLOYMENT_IN_PROGRESS", ""), poll, true},
+		{"flow violation fails", preflightErr(428, "FLOW_VIOLATION", ""), 0, false},
+		{"unknown code fails", preflightErr(428, "SOMETHING_NEW", ""), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, _, waitable := preflightDelay(tt.err, now, poll)
+
+			if waitable != tt.wantWaitable {
+				t.Errorf("Expected waitable=%v, got %v", tt.wantWaitable, waitable)
+			}
+			if delay != tt.wantDelay {
+				t.Errorf("Expected delay %s, got %s", tt.wantDelay, delay)
+			}
+		})
+	}
+}
+
+// fakeClock advances time only when sleep is called
+type fakeClock struct {
+	t     time.Time
+	slept time.Duration
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) sleep(ctx context.Context, d time.Duration) error {
+	c.t = c.t.Add(d)
+	c.slept += d
+	return ctx.Err()
+}
+
+func newTestWait(clock *fakeClock, maxWait time.Duration) *waitOptions {
+	return &waitOptions{
+		MaxWait:      maxWait,
+		PollInterval: 30 * time.Second,
+		now:          clock.now,
+		sleep:        clock.sleep,
+	}
+}
+
+func TestWaitResubmitsOnceChecksPass(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	opts := newTestWait(clock, 30*time.Minute)
+
+	submits, checks := 0, 0
+	submit := func() error {
+		submits++
+		if submits == 1 {
+			return preflightErr(428, "APPROVAL_REQUIRED", "")
+		}
+		return nil
+	}
+	check := func() error {
+		checks++
+		if checks < 3 {
+			return preflightErr(428, "APPROVAL_REQUIRED", "")
+		}
+		return nil
+	}
+
+	if err := opts.wait(context.Background(), submit, check); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if submits != 2 {
+		t.Errorf("Expected 2 submissions, got %d", submits)
+	}
+	if checks != 3 {
+		t.Errorf("Expected 3 checks, got %d", checks)
+	}
+	if clock.slept != 90*time.Second {
+		t.Errorf("Expected to wait 90s, waited %s", clock.slept)
+	}
+}
+
+func TestWaitUntilRetryAfter(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	opts := newTestWait(clock, time.Hour)
+
+	calls := 0
+	submit := func() error {
+		calls++
+		if clock.t.Before(time.Date(2025, 1, 1, 12, 20, 0, 0, time.UTC)) {
+			return preflightErr(423, "NO_DEPLOY_WINDOW", "2025-01-01T12:20:00Z")
+		}
+		return nil
+	}
+
+	if err := opts.wait(context.Background(), submit, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+	if clock.slept != 20*time.Minute {
+		t.Errorf("Expected to wait 20m, waited %s", clock.slept)
+	}
+}
+
+func TestWaitGivesUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       *api.APIError
+		wantSlept time.Duration
+	}{
+		{"max wait elapses", preflightErr(428, "APPROVAL_REQUIRED", ""), 5 * time.Minute},
+		{"retry_after beyond max wait", preflightErr(423, "NO_DEPLOY_WINDOW", "2025-01-01T18:00:00Z"), 0},
+		{"rule that can't be waited out", preflightErr(428, "FLOW_VIOLATION", ""), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+			opts := newTestWait(clock, 5*time.Minute)
+
+			submit := func() error { return tt.err }
+
+			err := opts.wait(context.Background(), submit, nil)
+			if err != tt.err {
+				t.Errorf("Expected the preflight error, got %v", err)
+			}
+			if clock.slept != tt.wantSlept {
+				t.Errorf("Expected to wait %s, waited %s", tt.wantSlept, clock.slept)
+			}
+		})
+	}
+}
+
+func TestWaitCancelled(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	opts := newTestWait(clock, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	submit := func() error { return preflightErr(428, "APPROVAL_REQUIRED", "") }
+
+	err := opts.wait(ctx, submit, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWaitDoesNotRetryOtherErrors(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	opts := newTestWait(clock, time.Hour)
+
+	calls := 0
+	submit := func() error {
+		calls++
+		return &api.APIError{StatusCode: 401, Detail: "unauthorized"}
+	}
+
+	if err := opts.wait(context.Background(), submit, nil); err == nil {
+		t.Error("Expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
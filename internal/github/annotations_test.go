@@ -1,7 +1,6 @@
 package github
 
 import (
-	"os"
 	"testing"
 )
 
@@ -103,63 +102,6 @@ func TestEscapeWorkflowCommand(t *testing.T) {
 	}
 }
 
-func TestWriteErrorAnnotation_NotInGitHub(t *testing.T) {
-	// Ensure GITHUB_ACTIONS is not set
-	os.Unsetenv("GITHUB_ACTIONS")
-
-	// Should not panic or error when not in GitHub Actions
-	WriteErrorAnnotation(423, "NO_DEPLOY_WINDOW", "Test message", "Test Rule", "", nil)
-}
-
-func TestWriteErrorAnnotation_InGitHub(t *testing.T) {
-	// Set GitHub Actions environment
-	os.Setenv("GITHUB_ACTIONS", "true")
-	defer os.Unsetenv("GITHUB_ACTIONS")
-
-	// Create a temporary file for GITHUB_STEP_SUMMARY
-	tmpFile, err := os.CreateTemp("", "github-summary-*.md")
-	if err != nil {
-		t.Fatalf("Failed to create temp file: %v", err)
-	}
-	defer os.Remove(tmpFile.Name())
-	tmpFile.Close()
-
-	os.Setenv("GITHUB_STEP_SUMMARY", tmpFile.Name())
-	defer os.Unsetenv("GITHUB_STEP_SUMMARY")
-
-	// Call the function
-	details := map[string]interface{}{
-		"rule_name":    "Test Rule",
-		"window_start": "2025-11-27T00:00:00Z",
-	}
-	WriteErrorAnnotation(423, "NO_DEPLOY_WINDOW", "No deployments allowed", "Test Rule", "2025-11-27T23:59:59Z", details)
-
-	// Read the summary file
-	content, err := os.ReadFile(tmpFile.Name())
-	if err != nil {
-		t.Fatalf("Failed to read summary file: %v", err)
-	}
-
-	summary := string(content)
-
-	// Verify key content is present
-	if !contains(summary, "Versioner Deployment Rejected") {
-		t.Error("Summary should contain 'Versioner Deployment Rejected'")
-	}
-	if !contains(summary, "NO_DEPLOY_WINDOW") {
-		t.Error("Summary should contain error code")
-	}
-	if !contains(summary, "Test Rule") {
-		t.Error("Summary should contain rule name")
-	}
-	if !contains(summary, "No deployments allowed") {
-		t.Error("Summary should contain message")
-	}
-	if !contains(summary, "2025-11-27T23:59:59Z") {
-		t.Error("Summary should contain retry_after timestamp")
-	}
-}
-
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && findSubstring(s, substr))
 }
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/versioner-io/versioner-cli/internal/version"
+)
+
+// defaultAPIURL is used when GITHUB_API_URL is not set
+const defaultAPIURL = "https://api.github.com"
+
+// Client calls the GitHub REST API on behalf of one repository
+type Client struct {
+	APIURL     string
+	Token      string
+	Repository string // owner/repo
+	HTTPClient *http.Client
+	UserAgent  string
+}
+
+// NewClientFromEnv returns a client for the repository of the current
+// GitHub Actions run, or nil if not running in GitHub Actions or GITHUB_TOKEN is not set
+func NewClientFromEnv() *Client {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return nil
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	if token == "" || repository == "" {
+		return nil
+	}
+
+	apiURL := os.Getenv("GITHUB_API_URL")
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+
+	return &Client{
+		APIURL:     apiURL,
+		Token:      token,
+		Repository: repository,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		UserAgent:  version.GetUserAgent(),
+	}
+}
+
+// do performs a request against the repository's GitHub REST API endpoint and decodes the response into out
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	endpoint := strings.TrimRight(c.APIURL, "/") + "/repos/" + c.Repository + path
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GitHub API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read GitHub API response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("GitHub API returned HTTP %d: %s", resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("GitHub API returned HTTP %d", resp.StatusCode)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("failed to parse GitHub API response: %w", err)
+		}
+	}
+	return nil
+}
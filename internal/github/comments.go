@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/versioner-io/versioner-cli/internal/report"
+)
+
+// commentsPageSize is the number of issue comments requested per page when looking for an existing comment
+const commentsPageSize = 100
+
+// pullRequest is the subset of a GitHub pull request the client uses
+type pullRequest struct {
+	Number int `json:"number"`
+}
+
+// issueComment is the subset of a GitHub issue comment the client uses
+type issueComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// PullRequestsForCommit returns the numbers of the pull requests associated with a commit
+// (the PRs it belongs to, or was merged by)
+func (c *Client) PullRequestsForCommit(ctx context.Context, sha string) ([]int, error) {
+	var pulls []pullRequest
+	if err := c.do(ctx, http.MethodGet, "/commits/"+sha+"/pulls", nil, &pulls); err != nil {
+		return nil, err
+	}
+
+	numbers := make([]int, 0, len(pulls))
+	for _, pr := range pulls {
+		numbers = append(numbers, pr.Number)
+	}
+	return numbers, nil
+}
+
+// UpsertComment creates a comment on a pull request, or updates the existing comment
+// starting with marker so repeated runs keep a single comment up to date. Comments that
+// only quote or mention the marker, as people's replies might, are left alone.
+func (c *Client) UpsertComment(ctx context.Context, number int, marker, body string) error {
+	if !strings.HasPrefix(body, marker) {
+		body = marker + "\n" + body
+	}
+	payload := map[string]string{"body": body}
+
+	for page := 1; ; page++ {
+		var comments []issueComment
+		path := fmt.Sprintf("/issues/%d/comments?per_page=%d&page=%d", number, commentsPageSize, page)
+		if err := c.do(ctx, http.MethodGet, path, nil, &comments); err != nil {
+			return err
+		}
+
+		for _, comment := range comments {
+			if strings.HasPrefix(comment.Body, marker) {
+				return c.do(ctx, http.MethodPatch, fmt.Sprintf("/issues/comments/%d", comment.ID), payload, nil)
+			}
+		}
+
+		if len(comments) < commentsPageSize {
+			break
+		}
+	}
+
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/issues/%d/comments", number), payload, nil)
+}
+
+// DeploymentRow is one environment in a pull request deployment comment
+type DeploymentRow struct {
+	Environment string
+	Version     string
+	Status      string
+	DeployedAt  time.Time
+	ViewURL     string
+}
+
+// DeploymentCommentMarker returns the hidden marker identifying the deployment comment of a product
+func DeploymentCommentMarker(product string) string {
+	return fmt.Sprintf("<!-- versioner-deployments:%s -->", product)
+}
+
+// FormatDeploymentComment renders the pull request comment listing where a product's
+// commit has been deployed, one row per environment
+func FormatDeploymentComment(product string, rows []DeploymentRow) string {
+	var b strings.Builder
+
+	b.WriteString(DeploymentCommentMarker(product) + "\n")
+	fmt.Fprintf(&b, "### 🚀 Deployments of `%s`\n\n", product)
+	b.WriteString("| Environment | Version | Status | Deployed |\n")
+	b.WriteString("|-------------|---------|--------|----------|\n")
+
+	for _, row := range rows {
+		version := fmt.Sprintf("`%s`", row.Version)
+		if row.ViewURL != "" {
+			version = fmt.Sprintf("[`%s`](%s)", row.Version, row.ViewURL)
+		}
+
+		deployedAt := "-"
+		if !row.DeployedAt.IsZero() {
+			deployedAt = row.DeployedAt.UTC().Format("2006-01-02 15:04 UTC")
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", row.Environment, version, report.FormatStatus(row.Status), deployedAt)
+	}
+
+	b.WriteString("\n<sub>Updated by Versioner on each deployment of this commit</sub>\n")
+	return b.String()
+}
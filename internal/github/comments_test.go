@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeComments is a minimal GitHub issue comments API for one pull request
+type fakeComments struct {
+	comments []issueComment
+	requests []string
+}
+
+func (f *fakeComments) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+
+	var body struct {
+		Body string `json:"body"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/api/commits/abc123/pulls":
+		_, _ = w.Write([]byte(`[{"number":7},{"number":9}]`))
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/api/issues/7/comments":
+		_ = json.NewEncoder(w).Encode(f.comments)
+	case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/api/issues/7/comments":
+		f.comments = append(f.comments, issueComment{ID: int64(len(f.comments) + 1), Body: body.Body})
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/repos/acme/api/issues/comments/"):
+		var id int64
+		_, _ = fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/repos/acme/api/issues/comments/"), "%d", &id)
+		for i := range f.comments {
+			if f.comments[i].ID == id {
+				f.comments[i].Body = body.Body
+			}
+		}
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+}
+
+func TestPullRequestsForCommit(t *testing.T) {
+	server := httptest.NewServer(&fakeComments{})
+	defer server.Close()
+
+	numbers, err := newTestClient(server.URL).PullRequestsForCommit(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(numbers) != 2 || numbers[0] != 7 || numbers[1] != 9 {
+		t.Errorf("Expected PRs [7 9], got %v", numbers)
+	}
+}
+
+func TestUpsertComment(t *testing.T) {
+	marker := DeploymentCommentMarker("api")
+	// People quoting or copying the marker must not have their comments overwritten
+	quoted := "> " + marker + "\n> | production | 1.2.2 |\n\nWhy is 1.2.2 still in production?"
+	copied := "See the table below\n" + marker
+	fake := &fakeComments{comments: []issueComment{{ID: 1, Body: "Looks good to me"}, {ID: 2, Body: quoted}, {ID: 3, Body: copied}}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	client := newTestClient(server.URL)
+
+	if err := client.UpsertComment(context.Background(), 7, marker, marker+"\nfirst"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := client.UpsertComment(context.Background(), 7, marker, marker+"\nsecond"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(fake.comments) != 4 {
+		t.Fatalf("Expected 4 comments, got %d", len(fake.comments))
+	}
+	for i, want := range []string{"Looks good to me", quoted, copied} {
+		if fake.comments[i].Body != want {
+			t.Errorf("Expected other comments to be left alone, got %q", fake.comments[i].Body)
+		}
+	}
+	if fake.comments[3].Body != marker+"\nsecond" {
+		t.Errorf("Expected the marked comment to be updated, got %q", fake.comments[3].Body)
+	}
+}
+
+func TestFormatDeploymentComment(t *testing.T) {
+	rows := []DeploymentRow{
+		{Environment: "production", Version: "1.2.3", Status: "completed", DeployedAt: time.Date(2025, 1, 6, 9, 30, 0, 0, time.UTC), ViewURL: "https://app.versioner.io/manage/deployments?view=evt-1"},
+		{Environment: "staging", Version: "1.2.3", Status: "failed"},
+	}
+
+	comment := FormatDeploymentComment("api", rows)
+
+	expected := []string{
+		"<!-- versioner-deployments:api -->",
+		"| production | [`1.2.3`](https://app.versioner.io/manage/deployments?view=evt-1) | ✅ completed | 2025-01-06 09:30 UTC |",
+		"| staging | `1.2.3` | ❌ failed | - |",
+	}
+	for _, want := range expected {
+		if !strings.Contains(comment, want) {
+			t.Errorf("Expected comment to contain %q, got:\n%s", want, comment)
+		}
+	}
+}
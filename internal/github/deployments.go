@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/versioner-io/versioner-cli/internal/status"
+)
+
+// DeploymentUpdate describes a Versioner deployment event to mirror on GitHub
+type DeploymentUpdate struct {
+	SHA         string
+	Environment string
+	Version     string
+	Status      string // Versioner status (canonical or alias)
+	ViewURL     string // Versioner UI link, used as environment_url and log_url
+}
+
+// deployment is the subset of a GitHub Deployment the client uses
+type deployment struct {
+	ID int64 `json:"id"`
+}
+
+// ReportDeployment mirrors a deployment event on GitHub and returns the ID of the GitHub
+// Deployment. Pending and started events create a new deployment; terminal events post
+// a status to the most recent deployment of the SHA to the environment, creating one
+// if there is none (e.g. when the started event was not tracked).
+func (c *Client) ReportDeployment(ctx context.Context, update DeploymentUpdate) (int64, error) {
+	if update.SHA == "" {
+		return 0, fmt.Errorf("a commit SHA is required to create a GitHub deployment")
+	}
+
+	state, ok := deploymentState(update.Status)
+	if !ok {
+		return 0, fmt.Errorf("status %q has no GitHub deployment state", update.Status)
+	}
+
+	var id int64
+	if status.IsTerminal(update.Status) {
+		latest, err := c.latestDeployment(ctx, update.SHA, update.Environment)
+		if err != nil {
+			return 0, err
+		}
+		id = latest
+	}
+	if id == 0 {
+		created, err := c.createDeployment(ctx, update)
+		if err != nil {
+			return 0, err
+		}
+		id = created
+	}
+
+	if err := c.createDeploymentStatus(ctx, id, state, update); err != nil {
+		return id, err
+	}
+	return id, nil
+}
+
+// deploymentState maps a Versioner status to a GitHub deployment status state
+func deploymentState(statusValue string) (string, bool) {
+	switch status.GetCanonical(statusValue) {
+	case status.Pending:
+		return "queued", true
+	case status.Started:
+		return "in_progress", true
+	case status.Completed:
+		return "success", true
+	case status.Failed:
+		return "failure", true
+	case status.Aborted:
+		return "error", true
+	}
+	return "", false
+}
+
+// latestDeployment returns the ID of the most recent deployment of sha to environment, or 0 if there is none
+func (c *Client) latestDeployment(ctx context.Context, sha, environment string) (int64, error) {
+	query := url.Values{}
+	query.Set("sha", sha)
+	query.Set("environment", environment)
+	query.Set("per_page", "1")
+
+	var deployments []deployment
+	if err := c.do(ctx, http.MethodGet, "/deployments?"+query.Encode(), nil, &deployments); err != nil {
+		return 0, err
+	}
+	if len(deployments) == 0 {
+		return 0, nil
+	}
+	return deployments[0].ID, nil
+}
+
+// createDeployment creates a GitHub Deployment for the update's SHA and environment
+func (c *Client) createDeployment(ctx context.Context, update DeploymentUpdate) (int64, error) {
+	body := map[string]interface{}{
+		"ref":         update.SHA,
+		"environment": update.Environment,
+		"description": fmt.Sprintf("Version %s (tracked by Versioner)", update.Version),
+		"payload":     map[string]string{"version": update.Version},
+		// The deploy is already happening - don't merge branches or wait for status checks
+		"auto_merge":        false,
+		"required_contexts": []string{},
+	}
+
+	var created deployment
+	if err := c.do(ctx, http.MethodPost, "/deployments", body, &created); err != nil {
+		return 0, err
+	}
+	if created.ID == 0 {
+		return 0, fmt.Errorf("GitHub did not create a deployment")
+	}
+	return created.ID, nil
+}
+
+// createDeploymentStatus posts a deployment status to a GitHub Deployment
+func (c *Client) createDeploymentStatus(ctx context.Context, id int64, state string, update DeploymentUpdate) error {
+	body := map[string]interface{}{
+		"state":       state,
+		"description": fmt.Sprintf("Version %s %s", update.Version, status.GetCanonical(update.Status)),
+	}
+	if update.ViewURL != "" {
+		body["environment_url"] = update.ViewURL
+		body["log_url"] = update.ViewURL
+	}
+
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/deployments/%d/statuses", id), body, nil)
+}
@@ -0,0 +1,210 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGitHub is a minimal GitHub Deployments API for one repository
+type fakeGitHub struct {
+	mu          sync.Mutex
+	deployments []map[string]interface{}
+	statuses    map[int64][]map[string]interface{}
+	authHeaders []string
+}
+
+func newFakeGitHub() (*fakeGitHub, *httptest.Server) {
+	f := &fakeGitHub{statuses: map[int64][]map[string]interface{}{}}
+	return f, httptest.NewServer(f)
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.authHeaders = append(f.authHeaders, r.Header.Get("Authorization"))
+	w.Header().Set("Content-Type", "application/json")
+
+	const base = "/repos/acme/api/deployments"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == base:
+		// Most recent first, like GitHub
+		matches := []map[string]interface{}{}
+		for i := len(f.deployments) - 1; i >= 0; i-- {
+			d := f.deployments[i]
+			if d["sha"] == r.URL.Query().Get("sha") && d["environment"] == r.URL.Query().Get("environment") {
+				matches = append(matches, d)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(matches)
+
+	case r.Method == http.MethodPost && r.URL.Path == base:
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		d := map[string]interface{}{
+			"id":          int64(len(f.deployments) + 1),
+			"sha":         body["ref"],
+			"environment": body["environment"],
+		}
+		f.deployments = append(f.deployments, d)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(d)
+
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, base+"/") && strings.HasSuffix(r.URL.Path, "/statuses"):
+		var id int64
+		_, _ = fmt.Sscanf(strings.TrimPrefix(r.URL.Path, base+"/"), "%d/statuses", &id)
+		if id < 1 || id > int64(len(f.deployments)) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.statuses[id] = append(f.statuses[id], body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1}`))
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+}
+
+func newTestClient(url string) *Client {
+	return &Client{APIURL: url, Token: "ghs_test", Repository: "acme/api", HTTPClient: http.DefaultClient}
+}
+
+func TestReportDeployment_Lifecycle(t *testing.T) {
+	fake, server := newFakeGitHub()
+	defer server.Close()
+	client := newTestClient(server.URL)
+
+	update := DeploymentUpdate{
+		SHA:         "abc123",
+		Environment: "production",
+		Version:     "1.2.3",
+		Status:      "started",
+		ViewURL:     "https://app.versioner.io/manage/deployments?view=evt-1",
+	}
+
+	startedID, err := client.ReportDeployment(context.Background(), update)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	update.Status = "success"
+	completedID, err := client.ReportDeployment(context.Background(), update)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if startedID != completedID {
+		t.Errorf("Expected the completed status on deployment %d, got %d", startedID, completedID)
+	}
+	if len(fake.deployments) != 1 {
+		t.Fatalf("Expected 1 deployment, got %d", len(fake.deployments))
+	}
+
+	statuses := fake.statuses[startedID]
+	if len(statuses) != 2 {
+		t.Fatalf("Expected 2 statuses, got %d", len(statuses))
+	}
+	if statuses[0]["state"] != "in_progress" || statuses[1]["state"] != "success" {
+		t.Errorf("Expected in_progress then success, got %v then %v", statuses[0]["state"], statuses[1]["state"])
+	}
+	if statuses[1]["environment_url"] != update.ViewURL || statuses[1]["log_url"] != update.ViewURL {
+		t.Errorf("Expected the Versioner view URL as environment_url and log_url, got %v", statuses[1])
+	}
+	for _, header := range fake.authHeaders {
+		if header != "Bearer ghs_test" {
+			t.Errorf("Expected bearer token authentication, got %q", header)
+		}
+	}
+}
+
+func TestReportDeployment_TerminalWithoutStarted(t *testing.T) {
+	fake, server := newFakeGitHub()
+	defer server.Close()
+	client := newTestClient(server.URL)
+
+	id, err := client.ReportDeployment(context.Background(), DeploymentUpdate{SHA: "abc123", Environment: "staging", Version: "1.2.3", Status: "failed"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(fake.deployments) != 1 {
+		t.Fatalf("Expected a deployment to be created, got %d", len(fake.deployments))
+	}
+	if state := fake.statuses[id][0]["state"]; state != "failure" {
+		t.Errorf("Expected state failure, got %v", state)
+	}
+}
+
+func TestReportDeployment_Errors(t *testing.T) {
+	_, server := newFakeGitHub()
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if _, err := client.ReportDeployment(context.Background(), DeploymentUpdate{Environment: "production", Status: "started"}); err == nil {
+		t.Error("Expected an error without a SHA")
+	}
+
+	client.Repository = "acme/missing"
+	_, err := client.ReportDeployment(context.Background(), DeploymentUpdate{SHA: "abc123", Environment: "production", Status: "started"})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 404: Not Found") {
+		t.Errorf("Expected the GitHub error message, got: %v", err)
+	}
+}
+
+func TestDeploymentState(t *testing.T) {
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{"pending", "queued"},
+		{"started", "in_progress"},
+		{"success", "success"},
+		{"completed", "success"},
+		{"failed", "failure"},
+		{"cancelled", "error"},
+	}
+
+	for _, tt := range tests {
+		if got, ok := deploymentState(tt.status); !ok || got != tt.want {
+			t.Errorf("deploymentState(%q) = %q, expected %q", tt.status, got, tt.want)
+		}
+	}
+	if _, ok := deploymentState("unknown"); ok {
+		t.Error("Expected no state for an unknown status")
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	os.Setenv("GITHUB_ACTIONS", "true")
+	os.Setenv("GITHUB_REPOSITORY", "acme/api")
+	os.Unsetenv("GITHUB_TOKEN")
+	defer os.Unsetenv("GITHUB_ACTIONS")
+	defer os.Unsetenv("GITHUB_REPOSITORY")
+
+	if client := NewClientFromEnv(); client != nil {
+		t.Error("Expected no client without a token")
+	}
+
+	os.Setenv("GITHUB_TOKEN", "ghs_test")
+	defer os.Unsetenv("GITHUB_TOKEN")
+
+	client := NewClientFromEnv()
+	if client == nil {
+		t.Fatal("Expected a client")
+	}
+	if client.APIURL != defaultAPIURL {
+		t.Errorf("Expected API URL %s, got %s", defaultAPIURL, client.APIURL)
+	}
+}
@@ -0,0 +1,41 @@
+package github
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// formatStepOutputs formats name/value pairs in the $GITHUB_OUTPUT file format,
+// using the heredoc-style delimiter syntax for values that span lines
+func formatStepOutputs(pairs [][2]string) string {
+	var b strings.Builder
+	for _, pair := range pairs {
+		name, value := pair[0], pair[1]
+		if value == "" {
+			continue
+		}
+
+		if !strings.ContainsAny(value, "\r\n") {
+			fmt.Fprintf(&b, "%s=%s\n", name, value)
+			continue
+		}
+
+		delimiter := outputDelimiter(value)
+		fmt.Fprintf(&b, "%s<<%s\n%s\n%s\n", name, delimiter, value, delimiter)
+	}
+	return b.String()
+}
+
+// outputDelimiter returns a random delimiter that does not occur in value
+func outputDelimiter(value string) string {
+	for {
+		buf := make([]byte, 8)
+		_, _ = rand.Read(buf)
+		delimiter := "ghadelimiter_" + hex.EncodeToString(buf)
+		if !strings.Contains(value, delimiter) {
+			return delimiter
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatStepOutputs(t *testing.T) {
+	got := formatStepOutputs([][2]string{
+		{"event_id", "evt-123"},
+		{"retry_after", ""},
+		{"status", "completed"},
+	})
+
+	want := "event_id=evt-123\nstatus=completed\n"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestFormatStepOutputs_Multiline(t *testing.T) {
+	got := formatStepOutputs([][2]string{{"message", "line one\nline two"}})
+
+	lines := strings.Split(got, "\n")
+	if len(lines) != 5 || lines[4] != "" {
+		t.Fatalf("Expected 4 lines, got %q", got)
+	}
+	if !strings.HasPrefix(lines[0], "message<<ghadelimiter_") {
+		t.Errorf("Expected a delimiter header, got %q", lines[0])
+	}
+	delimiter := strings.TrimPrefix(lines[0], "message<<")
+	if lines[1] != "line one" || lines[2] != "line two" {
+		t.Errorf("Expected the value on its own lines, got %q", got)
+	}
+	if lines[3] != delimiter {
+		t.Errorf("Expected closing delimiter %q, got %q", delimiter, lines[3])
+	}
+}
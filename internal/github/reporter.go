@@ -0,0 +1,97 @@
+package github
+
+import (
+	"io"
+	"os"
+
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/report"
+)
+
+func init() {
+	report.Register(cicd.SystemGitHub, func() report.Reporter { return NewReporter() })
+}
+
+// Reporter publishes command outcomes as GitHub Actions annotations, job summaries and step outputs
+type Reporter struct {
+	report.Base
+
+	// Stderr receives workflow commands (the runner processes them on both streams,
+	// and stdout is reserved for --output documents)
+	Stderr io.Writer
+	// SummaryPath is the job summary file ($GITHUB_STEP_SUMMARY), if any
+	SummaryPath string
+	// OutputPath is the step outputs file ($GITHUB_OUTPUT), if any
+	OutputPath string
+}
+
+// NewReporter returns a reporter for the current GitHub Actions step
+func NewReporter() *Reporter {
+	return &Reporter{
+		Stderr:      os.Stderr,
+		SummaryPath: os.Getenv("GITHUB_STEP_SUMMARY"),
+		OutputPath:  os.Getenv("GITHUB_OUTPUT"),
+	}
+}
+
+// Success writes a job summary for a successfully tracked event
+func (r *Reporter) Success(s report.Success) {
+	appendFile(r.SummaryPath, report.SuccessSummary(s))
+}
+
+// APIError writes an error annotation and job summary for a request the API rejected
+func (r *Reporter) APIError(f report.Failure) {
+	r.writeFailure(f, "API Error")
+}
+
+// NetworkError writes an error annotation and job summary for a request that could not reach the API
+func (r *Reporter) NetworkError(f report.Failure) {
+	r.writeFailure(f, "Network Error")
+}
+
+func (r *Reporter) writeFailure(f report.Failure, errorType string) {
+	_, _ = io.WriteString(r.Stderr, formatWorkflowCommand("error", "Versioner "+f.Action+" Failed", f.Message))
+	appendFile(r.SummaryPath, report.FailureSummary(f, errorType))
+}
+
+// PreflightRejection writes an error annotation and job summary, making the rejection
+// visible in the GitHub Actions UI without digging through logs
+func (r *Reporter) PreflightRejection(rej report.Rejection) {
+	_, _ = io.WriteString(r.Stderr, formatWorkflowCommand("error", formatTitle(rej.StatusCode, rej.Code, rej.RuleName), rej.Message))
+	appendFile(r.SummaryPath, report.RejectionSummary(rej))
+}
+
+// Warning writes a warning annotation
+func (r *Reporter) Warning(title, message string) {
+	_, _ = io.WriteString(r.Stderr, formatWorkflowCommand("warning", title, message))
+}
+
+// Result appends step outputs, so later steps can use them as ${{ steps.<id>.outputs.<name> }}.
+// Empty values are left out.
+func (r *Reporter) Result(res report.Result) {
+	appendFile(r.OutputPath, formatStepOutputs([][2]string{
+		{"event_id", res.EventID},
+		{"version_id", res.VersionID},
+		{"environment_id", res.EnvironmentID},
+		{"status", res.Status},
+		{"preflight_result", res.PreflightResult},
+		{"retry_after", res.RetryAfter},
+		{"ui_url", res.UIURL},
+	}))
+}
+
+// appendFile appends content to the file at path, if there is one
+func appendFile(path, content string) {
+	if path == "" || content == "" {
+		return
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		// Silently fail - don't break the CLI if we can't write the file
+		return
+	}
+	defer f.Close()
+
+	_, _ = f.WriteString(content)
+}
@@ -0,0 +1,140 @@
+package github
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/report"
+)
+
+func newTestReporter(t *testing.T) (*Reporter, *bytes.Buffer) {
+	dir := t.TempDir()
+	var stderr bytes.Buffer
+	return &Reporter{
+		Stderr:      &stderr,
+		SummaryPath: filepath.Join(dir, "summary.md"),
+		OutputPath:  filepath.Join(dir, "output"),
+	}, &stderr
+}
+
+func readFile(t *testing.T, path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(content)
+}
+
+func TestReporter_PreflightRejection(t *testing.T) {
+	r, stderr := newTestReporter(t)
+
+	r.PreflightRejection(report.Rejection{
+		StatusCode: 423,
+		Code:       "NO_DEPLOY_WINDOW",
+		Message:    "No deployments allowed",
+		RuleName:   "Test Rule",
+		RetryAfter: "2025-11-27T23:59:59Z",
+		Details: map[string]interface{}{
+			"rule_name":    "Test Rule",
+			"window_start": "2025-11-27T00:00:00Z",
+		},
+	})
+
+	if want := "::error title=Deployment Blocked: Test Rule::No deployments allowed\n"; stderr.String() != want {
+		t.Errorf("Expected annotation %q, got %q", want, stderr.String())
+	}
+
+	summary := readFile(t, r.SummaryPath)
+	for _, want := range []string{"Versioner Deployment Rejected", "NO_DEPLOY_WINDOW", "Test Rule", "No deployments allowed", "2025-11-27T23:59:59Z"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary should contain %q", want)
+		}
+	}
+}
+
+func TestReporter_APIError(t *testing.T) {
+	r, stderr := newTestReporter(t)
+
+	r.APIError(report.Failure{Action: "Build", Message: "invalid API key"})
+
+	if want := "::error title=Versioner Build Failed::invalid API key\n"; stderr.String() != want {
+		t.Errorf("Expected annotation %q, got %q", want, stderr.String())
+	}
+	if summary := readFile(t, r.SummaryPath); !strings.Contains(summary, "### API Error") {
+		t.Errorf("Expected an API error summary, got:\n%s", summary)
+	}
+}
+
+func TestReporter_Success(t *testing.T) {
+	r, _ := newTestReporter(t)
+
+	r.Success(report.Success{Action: "Deployment", Environment: "production", Status: "completed", Version: "1.2.3", UIURL: "https://app.versioner.io", ResourceID: "evt-1"})
+
+	summary := readFile(t, r.SummaryPath)
+	for _, want := range []string{"- **Environment:** production", "✅ completed", "`1.2.3`", "https://app.versioner.io/manage/deployments?view=evt-1"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary should contain %q, got:\n%s", want, summary)
+		}
+	}
+}
+
+func TestReporter_Warning(t *testing.T) {
+	r, stderr := newTestReporter(t)
+
+	r.Warning("Deployment Rule Warning: Soak", "line1\nline2")
+
+	if want := "::warning title=Deployment Rule Warning: Soak::line1%0Aline2\n"; stderr.String() != want {
+		t.Errorf("Expected %q, got %q", want, stderr.String())
+	}
+}
+
+func TestReporter_Result(t *testing.T) {
+	r, _ := newTestReporter(t)
+
+	// Existing outputs from earlier steps must be kept
+	if err := os.WriteFile(r.OutputPath, []byte("other=1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write output file: %v", err)
+	}
+
+	r.Result(report.Result{
+		EventID:         "evt-123",
+		VersionID:       "ver-456",
+		Status:          "started",
+		PreflightResult: "passed",
+		UIURL:           "https://app.versioner.io/manage/deployments?view=evt-123",
+	})
+
+	want := "other=1\n" +
+		"event_id=evt-123\n" +
+		"version_id=ver-456\n" +
+		"status=started\n" +
+		"preflight_result=passed\n" +
+		"ui_url=https://app.versioner.io/manage/deployments?view=evt-123\n"
+	if got := readFile(t, r.OutputPath); got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestReporter_NoFiles(t *testing.T) {
+	var stderr bytes.Buffer
+	r := &Reporter{Stderr: &stderr}
+
+	// Should not panic or error without summary and output files
+	r.Success(report.Success{Action: "Build", Status: "completed", Version: "1.2.3"})
+	r.Result(report.Result{EventID: "evt-1"})
+	r.NetworkError(report.Failure{Action: "Deployment", Message: "connection refused"})
+
+	if !strings.Contains(stderr.String(), "::error title=Versioner Deployment Failed::connection refused") {
+		t.Errorf("Expected the annotation without a summary file, got %q", stderr.String())
+	}
+}
+
+func TestReporterRegistered(t *testing.T) {
+	if len(report.ForSystem(cicd.SystemGitHub)) == 0 {
+		t.Error("Expected a reporter registered for GitHub Actions")
+	}
+}
@@ -0,0 +1,69 @@
+package gitlab
+
+import (
+	"os"
+	"strings"
+
+	"github.com/versioner-io/versioner-cli/internal/report"
+)
+
+// defaultDotenvFile is written in the working directory unless VERSIONER_DOTENV_FILE is set
+const defaultDotenvFile = "versioner.env"
+
+// newlineReplacer flattens multiline values
+var newlineReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// dotenvPath returns the path of the dotenv report file
+func dotenvPath() string {
+	if path := os.Getenv("VERSIONER_DOTENV_FILE"); path != "" {
+		return path
+	}
+	return defaultDotenvFile
+}
+
+// writeDotenv writes variables to the dotenv report file at path. Earlier values of the
+// same variables (e.g. from an earlier run in the same job) are replaced; other lines,
+// including variables written by other commands, are kept. Empty values are left out.
+func writeDotenv(path string, vars []report.Variable) {
+	if path == "" {
+		return
+	}
+
+	var existing string
+	if data, err := os.ReadFile(path); err == nil {
+		existing = string(data)
+	}
+
+	// Silently fail - don't break the CLI if we can't write the report
+	_ = os.WriteFile(path, []byte(formatDotenv(existing, vars)), 0644)
+}
+
+// formatDotenv merges vars into the contents of an existing dotenv file. Every variable
+// in vars is removed from the file, so an empty value clears a stale one.
+func formatDotenv(existing string, vars []report.Variable) string {
+	replaced := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		replaced[v.Name] = true
+	}
+
+	var b strings.Builder
+
+	for _, line := range strings.Split(existing, "\n") {
+		name, _, _ := strings.Cut(line, "=")
+		if line == "" || replaced[name] {
+			continue
+		}
+		b.WriteString(line + "\n")
+	}
+
+	for _, v := range vars {
+		if v.Value == "" {
+			continue
+		}
+		// Dotenv reports can't hold multiline values
+		value := newlineReplacer.Replace(v.Value)
+		b.WriteString(v.Name + "=" + value + "\n")
+	}
+
+	return b.String()
+}
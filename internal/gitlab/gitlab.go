@@ -0,0 +1,89 @@
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/report"
+)
+
+func init() {
+	report.Register(cicd.SystemGitLab, func() report.Reporter { return NewReporter() })
+}
+
+// Reporter publishes command outcomes in the GitLab job log and a dotenv report
+type Reporter struct {
+	report.Base
+
+	// Stderr receives job log output
+	Stderr io.Writer
+	// DotenvPath is the dotenv report file, for use with artifacts:reports:dotenv
+	DotenvPath string
+
+	// now is overridable in tests
+	now func() time.Time
+}
+
+// NewReporter returns a reporter for the current GitLab CI job
+func NewReporter() *Reporter {
+	return &Reporter{Stderr: os.Stderr, DotenvPath: dotenvPath()}
+}
+
+// StartSection opens a collapsed section in the job log. Everything written until the
+// matching EndSection is folded under header.
+func (r *Reporter) StartSection(name, header string) {
+	fmt.Fprintf(r.Stderr, "\x1b[0Ksection_start:%d:%s[collapsed=true]\r\x1b[0K%s\n", r.timestamp(), name, header)
+}
+
+// EndSection closes a section opened with StartSection
+func (r *Reporter) EndSection(name string) {
+	fmt.Fprintf(r.Stderr, "\x1b[0Ksection_end:%d:%s\r\x1b[0K\n", r.timestamp(), name)
+}
+
+func (r *Reporter) timestamp() int64 {
+	if r.now != nil {
+		return r.now().Unix()
+	}
+	return time.Now().Unix()
+}
+
+// PreflightRejection writes the rejection as a highlighted block in the job log, so it
+// stands out from the surrounding output without expanding any sections
+func (r *Reporter) PreflightRejection(rej report.Rejection) {
+	_, _ = io.WriteString(r.Stderr, formatErrorBlock(rej.StatusCode, rej.Code, rej.Message, rej.RuleName, rej.RetryAfter))
+}
+
+// Result writes the result variables to the dotenv report
+func (r *Reporter) Result(res report.Result) {
+	writeDotenv(r.DotenvPath, res.Variables)
+}
+
+// formatErrorBlock renders a preflight rejection with GitLab log colors
+func formatErrorBlock(statusCode int, errorCode, message, ruleName, retryAfter string) string {
+	var b strings.Builder
+
+	title := "Deployment blocked by Versioner"
+	if errorCode != "" {
+		title += " (" + errorCode + ")"
+	}
+
+	b.WriteString("\x1b[31;1m" + strings.Repeat("━", 60) + "\x1b[0m\n")
+	fmt.Fprintf(&b, "\x1b[31;1m✖ %s\x1b[0m\n", title)
+	fmt.Fprintf(&b, "  HTTP status: %d\n", statusCode)
+	if ruleName != "" {
+		fmt.Fprintf(&b, "  Rule: %s\n", ruleName)
+	}
+	if message != "" {
+		fmt.Fprintf(&b, "  %s\n", message)
+	}
+	if retryAfter != "" {
+		fmt.Fprintf(&b, "\x1b[33;1m  Retry after: %s\x1b[0m\n", retryAfter)
+	}
+	b.WriteString("\x1b[31;1m" + strings.Repeat("━", 60) + "\x1b[0m\n")
+
+	return b.String()
+}
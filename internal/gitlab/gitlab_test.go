@@ -0,0 +1,100 @@
+package gitlab
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+	"github.com/versioner-io/versioner-cli/internal/report"
+)
+
+func TestFormatDotenv(t *testing.T) {
+	existing := "BUILD_ID=42\nVERSIONER_EVENT_ID=evt-old\nVERSIONER_BUILD_VERSION=1.2.3\nVERSIONER_ERROR=blocked\n"
+	vars := []report.Variable{
+		{Name: "VERSIONER_EVENT_ID", Value: "evt-new"},
+		{Name: "VERSIONER_RETRY_AFTER", Value: ""},
+		{Name: "VERSIONER_ERROR", Value: ""},
+		{Name: "VERSIONER_MESSAGE", Value: "line one\nline two"},
+	}
+
+	got := formatDotenv(existing, vars)
+
+	// Only the variables being written are replaced
+	want := "BUILD_ID=42\nVERSIONER_BUILD_VERSION=1.2.3\nVERSIONER_EVENT_ID=evt-new\nVERSIONER_MESSAGE=line one line two\n"
+	if got != want {
+		t.Errorf("Expected:\n%q\ngot:\n%q", want, got)
+	}
+}
+
+func TestReporter_Result(t *testing.T) {
+	r := &Reporter{Stderr: &bytes.Buffer{}, DotenvPath: filepath.Join(t.TempDir(), "versioner.env")}
+
+	// A build step, then a deployment step in the same job
+	r.Result(report.Result{Variables: []report.Variable{{Name: "VERSIONER_EVENT_ID", Value: "evt-1"}, {Name: "VERSIONER_BUILD_NUMBER", Value: "42"}}})
+	r.Result(report.Result{Variables: []report.Variable{{Name: "VERSIONER_EVENT_ID", Value: "evt-2"}, {Name: "VERSIONER_STATUS", Value: "started"}}})
+
+	content, err := os.ReadFile(r.DotenvPath)
+	if err != nil {
+		t.Fatalf("Failed to read dotenv file: %v", err)
+	}
+	if string(content) != "VERSIONER_BUILD_NUMBER=42\nVERSIONER_EVENT_ID=evt-2\nVERSIONER_STATUS=started\n" {
+		t.Errorf("Expected the build step's other variables to be kept, got %q", content)
+	}
+}
+
+func TestReporter_Sections(t *testing.T) {
+	var stderr bytes.Buffer
+	r := &Reporter{Stderr: &stderr, now: func() time.Time { return time.Unix(1700000000, 0) }}
+
+	r.StartSection("versioner_event", "Tracking deployment event")
+	r.EndSection("versioner_event")
+
+	want := "\x1b[0Ksection_start:1700000000:versioner_event[collapsed=true]\r\x1b[0KTracking deployment event\n" +
+		"\x1b[0Ksection_end:1700000000:versioner_event\r\x1b[0K\n"
+	if stderr.String() != want {
+		t.Errorf("Expected %q, got %q", want, stderr.String())
+	}
+}
+
+func TestReporter_PreflightRejection(t *testing.T) {
+	var stderr bytes.Buffer
+	r := &Reporter{Stderr: &stderr}
+
+	r.PreflightRejection(report.Rejection{StatusCode: 423, Code: "NO_DEPLOY_WINDOW", Message: "No deploys on Fridays", RuleName: "Friday Freeze", RetryAfter: "2025-01-06T09:00:00Z"})
+
+	expected := []string{
+		"✖ Deployment blocked by Versioner (NO_DEPLOY_WINDOW)",
+		"HTTP status: 423",
+		"Rule: Friday Freeze",
+		"No deploys on Fridays",
+		"Retry after: 2025-01-06T09:00:00Z",
+	}
+	for _, want := range expected {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("Expected block to contain %q, got:\n%s", want, stderr.String())
+		}
+	}
+}
+
+func TestDotenvPath(t *testing.T) {
+	os.Unsetenv("VERSIONER_DOTENV_FILE")
+	if got := dotenvPath(); got != defaultDotenvFile {
+		t.Errorf("Expected %s, got %s", defaultDotenvFile, got)
+	}
+
+	os.Setenv("VERSIONER_DOTENV_FILE", "reports/versioner.env")
+	defer os.Unsetenv("VERSIONER_DOTENV_FILE")
+	if got := dotenvPath(); got != "reports/versioner.env" {
+		t.Errorf("Expected reports/versioner.env, got %s", got)
+	}
+}
+
+func TestReporterRegistered(t *testing.T) {
+	if len(report.ForSystem(cicd.SystemGitLab)) == 0 {
+		t.Error("Expected a reporter registered for GitLab CI")
+	}
+}
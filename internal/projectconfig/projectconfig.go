@@ -0,0 +1,288 @@
+// Package projectconfig reads the repository-level .versioner.yaml, which declares the
+// products built from a repository and per-environment overrides
+package projectconfig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/versioner-io/versioner-cli/internal/versionsource"
+	"go.yaml.in/yaml/v3"
+)
+
+// FileName is the name of the project config file
+const FileName = ".versioner.yaml"
+
+// SchemaVersion is the only schema version understood by this CLI
+const SchemaVersion = 1
+
+// File is a parsed project config file
+type File struct {
+	// Path is the file the config was read from
+	Path string `yaml:"-"`
+
+	Version      int                    `yaml:"version"`
+	Products     map[string]Product     `yaml:"products"`
+	Environments map[string]Environment `yaml:"environments"`
+}
+
+// Product declares a product built from the repository
+type Product struct {
+	// Paths are the directories of the product, relative to the config file. Commands
+	// run inside one of them default to this product.
+	Paths []string `yaml:"paths"`
+	// VersionFrom lists the version sources to try, as for --version-from
+	VersionFrom []string `yaml:"version_from"`
+	// Metadata is merged into the extra metadata of the product's events
+	Metadata map[string]interface{} `yaml:"metadata"`
+}
+
+// Environment holds overrides for events sent to an environment
+type Environment struct {
+	FailOnAPIError *bool                  `yaml:"fail_on_api_error"`
+	Metadata       map[string]interface{} `yaml:"metadata"`
+}
+
+// Find walks up from dir to the nearest project config file, stopping at the top of the
+// git repository (or the filesystem root). It returns "" if there is none.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		path := filepath.Join(dir, FileName)
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			return path, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Discover loads the project config file above dir, or returns nil if there is none
+func Discover(dir string) (*File, error) {
+	path, err := Find(dir)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Load reads and validates the project config file at path
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	f.Path = path
+	return f, nil
+}
+
+// Parse decodes and validates a project config. Unknown keys are rejected.
+func Parse(data []byte) (*File, error) {
+	f := &File{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, describeDecodeError(err)
+	}
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// unknownField matches the decoder's error for keys that aren't in the schema
+var unknownField = regexp.MustCompile(`field (\S+) not found in type \S+`)
+
+// describeDecodeError rewrites decoding errors in terms of the file rather than Go types,
+// one error per problem
+func describeDecodeError(err error) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+	errs := make([]error, 0, len(typeErr.Errors))
+	for _, e := range typeErr.Errors {
+		errs = append(errs, errors.New(unknownField.ReplaceAllString(e, "unknown key $1")))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the config against the schema, reporting every problem found
+func (f *File) Validate() error {
+	var errs []error
+
+	if f.Version != 0 && f.Version != SchemaVersion {
+		errs = append(errs, fmt.Errorf("version: unsupported schema version %d (expected %d)", f.Version, SchemaVersion))
+	}
+
+	for _, name := range sortedKeys(f.Products) {
+		p := f.Products[name]
+		if strings.TrimSpace(name) == "" {
+			errs = append(errs, errors.New("products: product name must not be empty"))
+		}
+		if len(f.Products) > 1 && len(p.Paths) == 0 {
+			errs = append(errs, fmt.Errorf("products.%s.paths: required when more than one product is declared", name))
+		}
+		for _, path := range p.Paths {
+			if err := validatePath(path); err != nil {
+				errs = append(errs, fmt.Errorf("products.%s.paths: %q %w", name, path, err))
+			}
+		}
+		for _, source := range p.VersionFrom {
+			if !versionsource.IsValid(source) {
+				errs = append(errs, fmt.Errorf("products.%s.version_from: unknown version source %q", name, source))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(f.Environments) {
+		if strings.TrimSpace(name) == "" {
+			errs = append(errs, errors.New("environments: environment name must not be empty"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validatePath checks that a product path is a directory inside the repository
+func validatePath(path string) error {
+	switch {
+	case path == "":
+		return errors.New("must not be empty")
+	case filepath.IsAbs(path) || strings.HasPrefix(path, "/"):
+		return errors.New("must be relative to the config file")
+	case path != "." && strings.HasPrefix(filepath.Clean(filepath.FromSlash(path)), ".."):
+		return errors.New("must not leave the repository")
+	}
+	return nil
+}
+
+// Dir returns the directory containing the config file, which product paths are relative to
+func (f *File) Dir() string {
+	return filepath.Dir(f.Path)
+}
+
+// Product returns the named product, or nil if it isn't declared
+func (f *File) Product(name string) *Product {
+	if f == nil {
+		return nil
+	}
+	if p, ok := f.Products[name]; ok {
+		return &p
+	}
+	return nil
+}
+
+// Environment returns the overrides for the named environment, or nil if there are none
+func (f *File) Environment(name string) *Environment {
+	if f == nil {
+		return nil
+	}
+	if e, ok := f.Environments[name]; ok {
+		return &e
+	}
+	return nil
+}
+
+// ProductFor returns the product whose paths contain dir, preferring the most specific
+// path. A single product without paths covers the whole repository. It returns "" if
+// no product matches.
+func (f *File) ProductFor(dir string) string {
+	if f == nil {
+		return ""
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	best, bestLen := "", -1
+	for _, name := range sortedKeys(f.Products) {
+		p := f.Products[name]
+		if len(p.Paths) == 0 && len(f.Products) == 1 {
+			return name
+		}
+		for _, path := range p.Paths {
+			root := filepath.Join(f.Dir(), filepath.FromSlash(path))
+			if within(dir, root) && len(root) > bestLen {
+				best, bestLen = name, len(root)
+			}
+		}
+	}
+	return best
+}
+
+// ProductDir returns the directory of a declared product: its first path, or the
+// config file's directory if it has none
+func (f *File) ProductDir(name string) string {
+	p := f.Product(name)
+	if p == nil {
+		return ""
+	}
+	if len(p.Paths) == 0 {
+		return f.Dir()
+	}
+	return filepath.Join(f.Dir(), filepath.FromSlash(p.Paths[0]))
+}
+
+// Metadata returns the extra metadata configured for events of product sent to
+// environment (either may be empty), with environment values taking precedence
+func (f *File) Metadata(product, environment string) map[string]interface{} {
+	metadata := map[string]interface{}{}
+	if p := f.Product(product); p != nil {
+		for k, v := range p.Metadata {
+			metadata[k] = v
+		}
+	}
+	if e := f.Environment(environment); e != nil {
+		for k, v := range e.Metadata {
+			metadata[k] = v
+		}
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// within reports whether dir is root or a directory below it
+func within(dir, root string) bool {
+	rel, err := filepath.Rel(root, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// sortedKeys returns the keys of m in order, so that validation errors and path
+// matching are deterministic
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,200 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sample = `version: 1
+products:
+  payments-api:
+    paths: [services/payments]
+    version_from: [package.json]
+    metadata:
+      team: payments
+      tier: 1
+  payments-worker:
+    paths: [services/payments/worker]
+  web:
+    paths: [apps/web]
+environments:
+  production:
+    fail_on_api_error: true
+    metadata:
+      tier: 0
+      change_managed: true
+`
+
+func writeConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	writeConfig(t, root, sample)
+	start := filepath.Join(root, "services", "payments", "src")
+	if err := os.MkdirAll(start, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := Discover(start)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if f == nil || f.Path != filepath.Join(root, FileName) {
+		t.Fatalf("Expected the config file in %s, got %+v", root, f)
+	}
+	if f.Environment("production").FailOnAPIError == nil || !*f.Environment("production").FailOnAPIError {
+		t.Error("Expected production to fail on API errors")
+	}
+}
+
+func TestFind_StopsAtRepositoryRoot(t *testing.T) {
+	outer := t.TempDir()
+	writeConfig(t, outer, sample)
+	repo := filepath.Join(outer, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := Find(repo)
+	if err != nil || path != "" {
+		t.Errorf("Expected no config file inside the repository, got %q (%v)", path, err)
+	}
+}
+
+func TestProductFor(t *testing.T) {
+	root := t.TempDir()
+	f, err := Load(writeConfig(t, root, sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir      string
+		expected string
+	}{
+		{"services/payments", "payments-api"},
+		{"services/payments/src/handlers", "payments-api"},
+		{"services/payments/worker", "payments-worker"},
+		{"services/payments-legacy", ""},
+		{"apps/web", "web"},
+		{".", ""},
+	}
+	for _, tt := range tests {
+		if got := f.ProductFor(filepath.Join(root, tt.dir)); got != tt.expected {
+			t.Errorf("ProductFor(%s) = %q, expected %q", tt.dir, got, tt.expected)
+		}
+	}
+
+	if dir := f.ProductDir("payments-api"); dir != filepath.Join(root, "services", "payments") {
+		t.Errorf("Unexpected product directory %s", dir)
+	}
+	if dir := f.ProductDir("unknown"); dir != "" {
+		t.Errorf("Expected no directory for an undeclared product, got %s", dir)
+	}
+}
+
+func TestProductFor_SingleProduct(t *testing.T) {
+	root := t.TempDir()
+	f, err := Load(writeConfig(t, root, "products:\n  api:\n    version_from: [git-tag]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.ProductFor(filepath.Join(root, "anywhere")); got != "api" {
+		t.Errorf("Expected the only product to cover the repository, got %q", got)
+	}
+	if dir := f.ProductDir("api"); dir != root {
+		t.Errorf("Expected the config directory, got %s", dir)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	f, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{"team": "payments", "tier": 0, "change_managed": true}
+	if got := f.Metadata("payments-api", "production"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := f.Metadata("web", "staging"); got != nil {
+		t.Errorf("Expected no metadata, got %v", got)
+	}
+
+	var missing *File
+	if got := missing.Metadata("web", "production"); got != nil {
+		t.Errorf("Expected no metadata without a config file, got %v", got)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "unknown key",
+			content:  "products:\n  api:\n    path: [api]\n",
+			expected: []string{"line 3: unknown key path"},
+		},
+		{
+			name:     "wrong type",
+			content:  "environments:\n  production:\n    fail_on_api_error: sometimes\n",
+			expected: []string{"sometimes"},
+		},
+		{
+			name:     "schema version",
+			content:  "version: 2\n",
+			expected: []string{"unsupported schema version 2"},
+		},
+		{
+			name:    "several problems",
+			content: "products:\n  api:\n    paths: [../elsewhere]\n    version_from: [setup.py]\n  web: {}\n",
+			expected: []string{
+				`products.api.paths: "../elsewhere" must not leave the repository`,
+				`products.api.version_from: unknown version source "setup.py"`,
+				"products.web.paths: required when more than one product is declared",
+			},
+		},
+		{
+			name:     "absolute path",
+			content:  "products:\n  api:\n    paths: [/srv/api]\n",
+			expected: []string{"must be relative to the config file"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.content))
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to contain %q, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	f, err := Parse(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if f.Product("api") != nil || f.Environment("production") != nil {
+		t.Error("Expected an empty config")
+	}
+}
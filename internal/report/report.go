@@ -0,0 +1,180 @@
+package report
+
+import (
+	"sync"
+
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+)
+
+// Reporter publishes the outcome of a command in a CI system's native format.
+// Implementations should embed Base and override the methods they support.
+type Reporter interface {
+	// Success reports a successfully tracked event
+	Success(s Success)
+	// APIError reports a request the API rejected (authentication, validation, ...)
+	APIError(f Failure)
+	// NetworkError reports a request that could not reach the API
+	NetworkError(f Failure)
+	// PreflightRejection reports a deployment blocked by preflight checks
+	PreflightRejection(r Rejection)
+	// Warning reports a non-blocking issue, such as a report_only rule that failed
+	Warning(title, message string)
+	// Result passes the outcome of an event command on to later CI steps
+	Result(r Result)
+	// StartSection and EndSection fold verbose output in the job log
+	StartSection(name, header string)
+	EndSection(name string)
+}
+
+// Success describes a successfully tracked build or deployment event
+type Success struct {
+	Action      string // "Build" or "Deployment"
+	Environment string
+	Status      string
+	Version     string
+	SCMSha      string
+	UIURL       string
+	ResourceID  string // event ID for deployments, version ID for builds
+}
+
+// Failure describes a failed event submission
+type Failure struct {
+	Action  string // "Build" or "Deployment"
+	Message string
+}
+
+// Rejection describes a deployment rejected by preflight checks
+type Rejection struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RuleName   string
+	RetryAfter string
+	Details    map[string]interface{}
+}
+
+// Result is the outcome of an event command, for use by later CI steps
+type Result struct {
+	EventID         string
+	VersionID       string
+	EnvironmentID   string
+	Status          string
+	PreflightResult string
+	RetryAfter      string
+	UIURL           string
+
+	// Variables are the VERSIONER_* variables, as written by --output=env
+	Variables []Variable
+}
+
+// Variable is a name/value pair passed to later CI steps
+type Variable struct {
+	Name  string
+	Value string
+}
+
+// Base implements Reporter by doing nothing
+type Base struct{}
+
+func (Base) Success(Success)                  {}
+func (Base) APIError(Failure)                 {}
+func (Base) NetworkError(Failure)             {}
+func (Base) PreflightRejection(Rejection)     {}
+func (Base) Warning(title, message string)    {}
+func (Base) Result(Result)                    {}
+func (Base) StartSection(name, header string) {}
+func (Base) EndSection(name string)           {}
+
+// Multi reports to several reporters in order
+type Multi []Reporter
+
+func (m Multi) Success(s Success) {
+	for _, r := range m {
+		r.Success(s)
+	}
+}
+
+func (m Multi) APIError(f Failure) {
+	for _, r := range m {
+		r.APIError(f)
+	}
+}
+
+func (m Multi) NetworkError(f Failure) {
+	for _, r := range m {
+		r.NetworkError(f)
+	}
+}
+
+func (m Multi) PreflightRejection(rej Rejection) {
+	for _, r := range m {
+		r.PreflightRejection(rej)
+	}
+}
+
+func (m Multi) Warning(title, message string) {
+	for _, r := range m {
+		r.Warning(title, message)
+	}
+}
+
+func (m Multi) Result(res Result) {
+	for _, r := range m {
+		r.Result(res)
+	}
+}
+
+func (m Multi) StartSection(name, header string) {
+	for _, r := range m {
+		r.StartSection(name, header)
+	}
+}
+
+func (m Multi) EndSection(name string) {
+	for _, r := range m {
+		r.EndSection(name)
+	}
+}
+
+// Factory creates a reporter, typically configured from the CI system's environment
+type Factory func() Reporter
+
+var (
+	registryMu sync.Mutex
+	registry   = map[cicd.System][]Factory{}
+	global     []Factory
+)
+
+// Register adds a reporter factory for a CI system. Several reporters can be
+// registered for the same system; all of them are used.
+func Register(system cicd.System, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[system] = append(registry[system], factory)
+}
+
+// RegisterGlobal adds a reporter factory that is used whatever the CI system, such as
+// one writing results to a file
+func RegisterGlobal(factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	global = append(global, factory)
+}
+
+// ForSystem returns the reporters registered for a CI system followed by the global
+// ones, or a reporter that does nothing if there are none
+func ForSystem(system cicd.System) Multi {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	reporters := Multi{}
+	for _, factory := range registry[system] {
+		reporters = append(reporters, factory())
+	}
+	for _, factory := range global {
+		reporters = append(reporters, factory())
+	}
+	return reporters
+}
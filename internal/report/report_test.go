@@ -0,0 +1,85 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/versioner-io/versioner-cli/internal/cicd"
+)
+
+// recorder records the calls it receives
+type recorder struct {
+	Base
+	calls *[]string
+	name  string
+}
+
+func (r recorder) Success(s Success) {
+	*r.calls = append(*r.calls, r.name+":success:"+s.Action)
+}
+
+func (r recorder) Warning(title, message string) {
+	*r.calls = append(*r.calls, r.name+":warning:"+title)
+}
+
+func TestMulti(t *testing.T) {
+	var calls []string
+	m := Multi{recorder{calls: &calls, name: "a"}, recorder{calls: &calls, name: "b"}}
+
+	m.Success(Success{Action: "Build"})
+	m.Warning("Rule", "message")
+	m.APIError(Failure{Action: "Build", Message: "ignored by Base"})
+
+	want := []string{"a:success:Build", "b:success:Build", "a:warning:Rule", "b:warning:Rule"}
+	if len(calls) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, calls)
+			break
+		}
+	}
+}
+
+func TestForSystem(t *testing.T) {
+	var calls []string
+	Register(cicd.SystemRundeck, func() Reporter { return recorder{calls: &calls, name: "ci"} })
+	Register(cicd.SystemRundeck, func() Reporter { return recorder{calls: &calls, name: "file"} })
+
+	reporters := ForSystem(cicd.SystemRundeck)
+	if len(reporters) != 2 {
+		t.Fatalf("Expected 2 reporters, got %d", len(reporters))
+	}
+	reporters.Success(Success{Action: "Deployment"})
+	if len(calls) != 2 {
+		t.Errorf("Expected both reporters to be called, got %v", calls)
+	}
+
+	// Systems without reporters get one that does nothing
+	if len(ForSystem(cicd.SystemUnknown)) != 0 {
+		t.Error("Expected no reporters for an unknown system")
+	}
+	ForSystem(cicd.SystemUnknown).Success(Success{})
+}
+
+func TestRegisterGlobal(t *testing.T) {
+	var calls []string
+	RegisterGlobal(func() Reporter { return recorder{calls: &calls, name: "file"} })
+	t.Cleanup(func() { global = nil })
+	Register(cicd.SystemJenkins, func() Reporter { return recorder{calls: &calls, name: "ci"} })
+
+	// Global reporters are used after the system's own, and without a CI system
+	ForSystem(cicd.SystemJenkins).Success(Success{Action: "Build"})
+	ForSystem(cicd.SystemUnknown).Success(Success{Action: "Deployment"})
+
+	want := []string{"ci:success:Build", "file:success:Build", "file:success:Deployment"}
+	if len(calls) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, calls)
+			break
+		}
+	}
+}
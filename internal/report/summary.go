@@ -0,0 +1,180 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SuccessSummary renders the markdown summary of a successfully tracked event
+func SuccessSummary(s Success) string {
+	var summary string
+	summary += "## 🚀 Versioner Summary\n\n"
+
+	// Add key information
+	summary += fmt.Sprintf("- **Action:** %s\n", s.Action)
+	if s.Environment != "" {
+		summary += fmt.Sprintf("- **Environment:** %s\n", s.Environment)
+	}
+	summary += fmt.Sprintf("- **Status:** %s\n", FormatStatus(s.Status))
+	summary += fmt.Sprintf("- **Version:** `%s`\n", s.Version)
+
+	if s.SCMSha != "" {
+		summary += fmt.Sprintf("- **Git SHA:** `%s`\n", s.SCMSha)
+	}
+
+	// Add "View in Versioner" link
+	if viewURL := ViewURL(s.UIURL, s.Action, s.ResourceID); viewURL != "" {
+		summary += fmt.Sprintf("\n[View in Versioner →](%s)\n", viewURL)
+	}
+
+	return summary
+}
+
+// FailureSummary renders the markdown summary of a failed event submission.
+// errorType is "API Error" or "Network Error".
+func FailureSummary(f Failure, errorType string) string {
+	var summary string
+	summary += fmt.Sprintf("## ❌ Versioner %s Failed\n\n", f.Action)
+	summary += fmt.Sprintf("### %s\n\n", errorType)
+	summary += fmt.Sprintf("**Error:** %s\n\n", f.Message)
+
+	summary += "**Possible Causes:**\n"
+	switch errorType {
+	case "API Error":
+		summary += "- Invalid API key or authentication failure\n"
+		summary += "- Validation error (check required fields)\n"
+		summary += "- API service unavailable\n"
+		summary += "- Rate limiting or quota exceeded\n"
+	case "Network Error":
+		summary += "- Network connectivity issues\n"
+		summary += "- DNS resolution failure\n"
+		summary += "- API endpoint unreachable\n"
+		summary += "- Timeout or connection refused\n"
+	default:
+		summary += "- Check error message above for details\n"
+	}
+
+	summary += "\n**Action Required:**\n"
+	summary += "- Verify your `VERSIONER_API_KEY` is set correctly\n"
+	summary += "- Check network connectivity to Versioner API\n"
+	summary += "- Review error message for specific guidance\n"
+	summary += "- Contact support if issue persists\n"
+
+	return summary
+}
+
+// RejectionSummary renders the detailed markdown summary of a preflight rejection
+func RejectionSummary(rej Rejection) string {
+	statusCode, errorCode, ruleName, retryAfter := rej.StatusCode, rej.Code, rej.RuleName, rej.RetryAfter
+
+	var summary string
+	summary += "## ❌ Versioner Deployment Rejected\n\n"
+
+	// Add status-specific emoji and title
+	switch statusCode {
+	case 409:
+		summary += "### ⚠️ Deployment Conflict\n\n"
+	case 423:
+		summary += "### 🔒 Deployment Blocked by Schedule\n\n"
+	case 428:
+		summary += "### ❌ Deployment Precondition Failed\n\n"
+	}
+
+	// Add key information
+	summary += fmt.Sprintf("- **Error Code:** `%s`\n", errorCode)
+	if ruleName != "" {
+		summary += fmt.Sprintf("- **Rule:** %s\n", ruleName)
+	}
+	summary += fmt.Sprintf("- **Message:** %s\n", rej.Message)
+
+	if retryAfter != "" {
+		summary += fmt.Sprintf("- **Retry After:** `%s`\n", retryAfter)
+	}
+
+	summary += "\n"
+
+	// Add specific guidance based on status code and error code
+	summary += "**Action Required:**\n"
+	switch statusCode {
+	case 409:
+		summary += "- Wait for the current deployment to complete\n"
+		summary += "- Retry this deployment\n"
+
+	case 423:
+		if retryAfter != "" {
+			summary += fmt.Sprintf("- Wait until `%s`\n", retryAfter)
+			summary += "- Retry automatically after the no-deploy window\n"
+		}
+		summary += "- Or use `--skip-preflight-checks` for emergencies\n"
+
+	case 428:
+		switch errorCode {
+		case "FLOW_VIOLATION":
+			summary += "- Deploy to required environments first\n"
+			summary += "- Then retry this deployment\n"
+
+		case "INSUFFICIENT_SOAK_TIME":
+			summary += "- Wait for the soak time requirement to be met\n"
+			if retryAfter != "" {
+				summary += fmt.Sprintf("- Can deploy at: `%s`\n", retryAfter)
+			}
+			summary += "- Or use `--skip-preflight-checks` for emergencies\n"
+
+		case "QUALITY_APPROVAL_REQUIRED", "APPROVAL_REQUIRED":
+			summary += "- Obtain required approval via Versioner UI\n"
+			summary += "- Then retry this deployment\n"
+
+		default:
+			summary += "- Resolve the issue described above\n"
+			summary += "- Then retry this deployment\n"
+			summary += "- Or use `--skip-preflight-checks` for emergencies\n"
+		}
+	}
+
+	// Add details section if available
+	if len(rej.Details) > 0 {
+		summary += "\n**Details:**\n"
+		summary += "```json\n"
+		detailsJSON, err := json.MarshalIndent(rej.Details, "", "  ")
+		if err == nil {
+			summary += string(detailsJSON)
+		}
+		summary += "\n```\n"
+	}
+
+	return summary
+}
+
+// FormatStatus adds an emoji to the status for visual clarity
+func FormatStatus(status string) string {
+	switch status {
+	case "started", "in_progress":
+		return "⏳ " + status
+	case "completed", "success":
+		return "✅ " + status
+	case "failed":
+		return "❌ " + status
+	case "aborted", "cancelled":
+		return "🚫 " + status
+	case "pending":
+		return "⏸️ " + status
+	default:
+		return status
+	}
+}
+
+// ViewURL returns the Versioner UI link for a tracked resource, or "" if there is none.
+// action is "Deployment" (resourceID is the event ID) or "Build" (resourceID is the version ID).
+func ViewURL(uiURL, action, resourceID string) string {
+	if uiURL == "" || resourceID == "" {
+		return ""
+	}
+
+	switch action {
+	case "Deployment":
+		return fmt.Sprintf("%s/manage/deployments?view=%s", uiURL, resourceID)
+	case "Build":
+		return fmt.Sprintf("%s/manage/versions?view=%s", uiURL, resourceID)
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuccessSummary(t *testing.T) {
+	summary := SuccessSummary(Success{
+		Action:      "Deployment",
+		Environment: "production",
+		Status:      "completed",
+		Version:     "1.2.3",
+		UIURL:       "https://app.versioner.io",
+		ResourceID:  "evt-1",
+	})
+
+	for _, want := range []string{"- **Environment:** production", "- **Status:** ✅ completed", "- **Version:** `1.2.3`", "(https://app.versioner.io/manage/deployments?view=evt-1)"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, summary)
+		}
+	}
+	if strings.Contains(summary, "Git SHA") {
+		t.Errorf("Expected no Git SHA line without a SHA, got:\n%s", summary)
+	}
+}
+
+func TestViewURL(t *testing.T) {
+	tests := []struct {
+		action     string
+		resourceID string
+		want       string
+	}{
+		{"Deployment", "evt-1", "https://app.versioner.io/manage/deployments?view=evt-1"},
+		{"Build", "ver-1", "https://app.versioner.io/manage/versions?view=ver-1"},
+		{"Deployment", "", ""},
+		{"Other", "x", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ViewURL("https://app.versioner.io", tt.action, tt.resourceID); got != tt.want {
+			t.Errorf("ViewURL(%q, %q) = %q, expected %q", tt.action, tt.resourceID, got, tt.want)
+		}
+	}
+}
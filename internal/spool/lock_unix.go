@@ -0,0 +1,18 @@
+//go:build !windows
+
+package spool
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile blocks until an exclusive advisory lock is held on f
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// unlockFile releases the lock held on f
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
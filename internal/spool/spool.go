@@ -0,0 +1,200 @@
+package spool
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// lockFileName is the lock file that serializes access to a spool directory
+	lockFileName = ".lock"
+	// rejectedDirName holds entries the API rejected permanently during a flush
+	rejectedDirName = "rejected"
+)
+
+// Entry is an event that could not be delivered to the Versioner API
+type Entry struct {
+	Kind           string          `json:"kind"`
+	QueuedAt       time.Time       `json:"queued_at"`
+	IdempotencyKey string          `json:"idempotency_key,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+
+	// Name is the entry's file name within the spool directory
+	Name string `json:"-"`
+}
+
+// Spool is a directory of undelivered events, replayed in the order they were queued
+type Spool struct {
+	Dir string
+}
+
+// New creates a spool backed by the given directory
+func New(dir string) *Spool {
+	return &Spool{Dir: dir}
+}
+
+// DefaultDir returns the default spool directory ($HOME/.versioner/spool)
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".versioner", "spool"), nil
+}
+
+// Enqueue persists an event payload and returns the path of the spool entry.
+// It takes the spool lock, so it is safe to call from concurrent processes.
+func (s *Spool) Enqueue(kind, idempotencyKey string, payload interface{}, cause error) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	entry := &Entry{
+		Kind:           kind,
+		QueuedAt:       time.Now().UTC(),
+		IdempotencyKey: idempotencyKey,
+		Payload:        data,
+	}
+	if cause != nil {
+		entry.LastError = cause.Error()
+	}
+
+	unlock, err := s.Lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	// Names sort in queue order: zero-padded nanosecond timestamp, then a random suffix
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	entry.Name = fmt.Sprintf("%019d-%s-%s.json", entry.QueuedAt.UnixNano(), hex.EncodeToString(suffix), kind)
+
+	path := filepath.Join(s.Dir, entry.Name)
+	if err := writeFileAtomic(path, entry); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// Lock acquires an exclusive lock on the spool directory, creating it if needed.
+// The returned function releases the lock.
+func (s *Spool) Lock() (func(), error) {
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.Dir, lockFileName), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spool lock: %w", err)
+	}
+
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock spool: %w", err)
+	}
+
+	return func() {
+		_ = unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// List returns the queued entries in the order they were queued.
+// Callers replaying entries should hold the lock (see Lock).
+func (s *Spool) List() ([]*Entry, error) {
+	files, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read spool directory: %w", err)
+	}
+
+	var names []string
+	for _, f := range files {
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		names = append(names, f.Name())
+	}
+	sort.Strings(names)
+
+	entries := make([]*Entry, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(s.Dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spool entry %s: %w", name, err)
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("invalid spool entry %s: %w", name, err)
+		}
+		entry.Name = name
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
+
+// Remove deletes a delivered entry
+func (s *Spool) Remove(entry *Entry) error {
+	return os.Remove(filepath.Join(s.Dir, entry.Name))
+}
+
+// Reject moves an entry the API rejected permanently into the rejected/ subdirectory
+// and returns its new path
+func (s *Spool) Reject(entry *Entry, reason string) (string, error) {
+	rejectedDir := filepath.Join(s.Dir, rejectedDirName)
+	if err := os.MkdirAll(rejectedDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create rejected directory: %w", err)
+	}
+
+	entry.LastError = reason
+	path := filepath.Join(rejectedDir, entry.Name)
+	if err := writeFileAtomic(path, entry); err != nil {
+		return "", err
+	}
+
+	return path, s.Remove(entry)
+}
+
+// writeFileAtomic writes an entry to a temporary file and renames it into place,
+// so readers never observe a partially written entry
+func writeFileAtomic(path string, entry *Entry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal spool entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create spool entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write spool entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write spool entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write spool entry: %w", err)
+	}
+
+	return nil
+}
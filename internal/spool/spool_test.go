@@ -0,0 +1,109 @@
+package spool
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestEnqueueAndList(t *testing.T) {
+	s := New(t.TempDir())
+
+	payloads := []map[string]string{
+		{"product_name": "first"},
+		{"product_name": "second"},
+		{"product_name": "third"},
+	}
+	for _, p := range payloads {
+		if _, err := s.Enqueue("build", "key-"+p["product_name"], p, errors.New("connection refused")); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+
+	entries, err := s.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	for i, entry := range entries {
+		want := "key-" + payloads[i]["product_name"]
+		if entry.IdempotencyKey != want {
+			t.Errorf("Entry %d: expected idempotency key %s (queue order), got %s", i, want, entry.IdempotencyKey)
+		}
+		if entry.Kind != "build" {
+			t.Errorf("Entry %d: expected kind build, got %s", i, entry.Kind)
+		}
+		if entry.LastError != "connection refused" {
+			t.Errorf("Entry %d: expected last error to be recorded, got %q", i, entry.LastError)
+		}
+	}
+}
+
+func TestListMissingDirectory(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "does-not-exist"))
+
+	entries, err := s.List()
+	if err != nil {
+		t.Fatalf("Expected no error for missing directory, got: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no entries, got %d", len(entries))
+	}
+}
+
+func TestRemoveAndReject(t *testing.T) {
+	s := New(t.TempDir())
+
+	for _, kind := range []string{"build", "deployment"} {
+		if _, err := s.Enqueue(kind, "", map[string]string{}, nil); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+
+	entries, _ := s.List()
+	if err := s.Remove(entries[0]); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+
+	path, err := s.Reject(entries[1], "HTTP 422: validation error")
+	if err != nil {
+		t.Fatalf("Reject failed: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("Expected rejected entry at %s: %v", path, err)
+	}
+
+	remaining, _ := s.List()
+	if len(remaining) != 0 {
+		t.Errorf("Expected empty spool, got %d entries", len(remaining))
+	}
+}
+
+func TestConcurrentEnqueue(t *testing.T) {
+	s := New(t.TempDir())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.Enqueue("deployment", "", map[string]int{"n": 1}, nil); err != nil {
+				t.Errorf("Enqueue failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, err := s.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 20 {
+		t.Errorf("Expected 20 entries, got %d", len(entries))
+	}
+}
@@ -74,3 +74,13 @@ func GetCanonical(status string) string {
 	canonical, _ := Normalize(status)
 	return canonical
 }
+
+// IsTerminal checks if a status value (canonical or alias) marks the end of a build or deployment
+func IsTerminal(status string) bool {
+	switch GetCanonical(status) {
+	case Completed, Failed, Aborted:
+		return true
+	default:
+		return false
+	}
+}
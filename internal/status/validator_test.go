@@ -117,3 +117,26 @@ func TestGetCanonical(t *testing.T) {
 		}
 	}
 }
+
+func TestIsTerminal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"completed", true},
+		{"success", true},
+		{"failed", true},
+		{"cancelled", true},
+		{"started", false},
+		{"in_progress", false},
+		{"pending", false},
+		{"unknown", false},
+	}
+
+	for _, test := range tests {
+		result := IsTerminal(test.input)
+		if result != test.expected {
+			t.Errorf("IsTerminal(%q) = %v, expected %v", test.input, result, test.expected)
+		}
+	}
+}
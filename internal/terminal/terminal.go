@@ -0,0 +1,93 @@
+// Package terminal reads input from an interactive terminal
+package terminal
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// IsTerminal reports whether f is an interactive terminal
+func IsTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// ReadSecret reads a line from f without echoing it when f is a terminal, for API keys
+// and other secrets. The trailing newline is removed. If ctx is cancelled first (e.g. by
+// Ctrl-C), the terminal is restored and ctx's error is returned.
+func ReadSecret(ctx context.Context, f *os.File) (string, error) {
+	if !IsTerminal(f) {
+		return ReadLine(ctx, f)
+	}
+
+	fd := int(f.Fd())
+	state, err := term.GetState(fd)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := readContext(ctx, func() (string, error) {
+		b, err := term.ReadPassword(fd)
+		return string(b), err
+	})
+	if ctx.Err() != nil {
+		_ = term.Restore(fd, state)
+	}
+	// The newline typed by the user wasn't echoed
+	_, _ = os.Stderr.WriteString("\n")
+	return secret, err
+}
+
+// ReadLine reads up to the next newline one byte at a time, so that no input after it
+// is consumed and later reads from r (including ReadSecret) see the following lines.
+// If ctx is cancelled first (e.g. by Ctrl-C), ctx's error is returned.
+func ReadLine(ctx context.Context, r io.Reader) (string, error) {
+	return readContext(ctx, func() (string, error) {
+		return readLine(r)
+	})
+}
+
+// readContext runs read until it returns or ctx is cancelled. A cancelled read is left
+// blocked in the background; the command exits soon after.
+func readContext(ctx context.Context, read func() (string, error)) (string, error) {
+	type result struct {
+		line string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		line, err := read()
+		done <- result{line, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.line, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func readLine(r io.Reader) (string, error) {
+	var line strings.Builder
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+			line.WriteByte(buf[0])
+		}
+		if err == io.EOF && line.Len() > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimSuffix(line.String(), "\r"), nil
+}
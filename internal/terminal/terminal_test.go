@@ -0,0 +1,61 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadLine(t *testing.T) {
+	r := strings.NewReader("https://api.versioner.io\r\nsecret\nlast")
+	for _, expected := range []string{"https://api.versioner.io", "secret", "last"} {
+		line, err := ReadLine(context.Background(), r)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if line != expected {
+			t.Errorf("Expected %q, got %q", expected, line)
+		}
+	}
+	if _, err := ReadLine(context.Background(), r); err == nil {
+		t.Error("Expected an error at the end of input")
+	}
+}
+
+func TestReadLine_Cancelled(t *testing.T) {
+	// Nothing is ever written, as when nobody answers a prompt
+	r, w := io.Pipe()
+	defer w.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ReadLine(ctx, r); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestReadSecret_NotATerminal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(path, []byte("sk_live_123\nrest\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if IsTerminal(f) {
+		t.Fatal("Expected a regular file not to be a terminal")
+	}
+	if secret, err := ReadSecret(context.Background(), f); err != nil || secret != "sk_live_123" {
+		t.Errorf("Expected sk_live_123, got %q (%v)", secret, err)
+	}
+	if line, err := ReadLine(context.Background(), f); err != nil || line != "rest" {
+		t.Errorf("Expected the next line to be left unread, got %q (%v)", line, err)
+	}
+}
@@ -0,0 +1,169 @@
+// Package userconfig edits the user config file (~/.versioner/config.yaml) in place,
+// keeping comments and key order intact
+package userconfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// FileMode is the permission of written config files, which may hold API keys
+const FileMode = 0600
+
+// Document is a config file, held as a YAML node tree. Keys are dotted paths through
+// nested mappings, e.g. "profiles.staging.api_url".
+type Document struct {
+	// Path is the file the document is read from and saved to
+	Path string
+
+	doc *yaml.Node
+}
+
+// Load reads the config file at path. A missing file is an empty document.
+func Load(path string) (*Document, error) {
+	d := &Document{Path: path, doc: &yaml.Node{
+		Kind:    yaml.DocumentNode,
+		Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+	}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return d, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if doc.Kind == 0 {
+		// Empty file (or only comments)
+		return d, nil
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: expected a mapping of settings", path)
+	}
+	d.doc = &doc
+	return d, nil
+}
+
+// Decode decodes the whole document into v
+func (d *Document) Decode(v interface{}) error {
+	return d.root().Decode(v)
+}
+
+// Get returns the scalar value at key
+func (d *Document) Get(key string) (string, bool) {
+	node := d.root()
+	for _, part := range strings.Split(key, ".") {
+		node = lookup(node, part)
+		if node == nil {
+			return "", false
+		}
+	}
+	if node.Kind != yaml.ScalarNode {
+		return "", false
+	}
+	return node.Value, true
+}
+
+// Set sets key to value, creating intermediate mappings as needed. The value is encoded
+// with its Go type, so strings stay strings even when they look like numbers.
+func (d *Document) Set(key string, value interface{}) error {
+	parts := strings.Split(key, ".")
+	node := d.root()
+	for i, part := range parts {
+		if part == "" {
+			return fmt.Errorf("invalid key %q", key)
+		}
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("cannot set %s: %s is not a mapping", key, strings.Join(parts[:i], "."))
+		}
+
+		child := lookup(node, part)
+		if i == len(parts)-1 {
+			var encoded yaml.Node
+			if err := encoded.Encode(value); err != nil {
+				return err
+			}
+			if child != nil {
+				// Keep comments attached to the old value
+				encoded.HeadComment, encoded.LineComment, encoded.FootComment = child.HeadComment, child.LineComment, child.FootComment
+				*child = encoded
+			} else {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, &encoded)
+			}
+			return nil
+		}
+
+		if child == nil || (child.Kind == yaml.ScalarNode && child.Tag == "!!null") {
+			mapping := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if child != nil {
+				*child = *mapping
+				mapping = child
+			} else {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, mapping)
+			}
+			child = mapping
+		}
+		node = child
+	}
+	return nil
+}
+
+// Save writes the document to its path, readable only by the owner
+func (d *Document) Save() error {
+	if err := os.MkdirAll(filepath.Dir(d.Path), 0700); err != nil {
+		return err
+	}
+
+	data, err := marshal(d.doc)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(d.Path, data, FileMode); err != nil {
+		return err
+	}
+	// WriteFile only applies the mode to new files
+	return os.Chmod(d.Path, FileMode)
+}
+
+// root returns the top-level mapping
+func (d *Document) root() *yaml.Node {
+	return d.doc.Content[0]
+}
+
+// lookup returns the value of key in a mapping node, or nil
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// marshal encodes a document with the indentation used in the docs
+func marshal(doc *yaml.Node) ([]byte, error) {
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,119 @@
+package userconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := "# Default account\napi_key: abc # rotated monthly\nprofiles:\n  staging:\n    api_url: https://api.staging.versioner.io\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("api_key", "0123"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("profiles.staging.api_key", "staging-key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("profiles.unit-a.retry_max_attempts", 6); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("profile", "staging"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `# Default account
+api_key: "0123" # rotated monthly
+profiles:
+  staging:
+    api_url: https://api.staging.versioner.io
+    api_key: staging-key
+  unit-a:
+    retry_max_attempts: 6
+profile: staging
+`
+	if string(data) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, data)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != FileMode {
+		t.Errorf("Expected mode %o, got %o", FileMode, fi.Mode().Perm())
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := reloaded.Get("profiles.staging.api_key"); !ok || value != "staging-key" {
+		t.Errorf("Expected staging-key, got %q", value)
+	}
+	if _, ok := reloaded.Get("profiles.staging"); ok {
+		t.Error("Expected no scalar value for a mapping")
+	}
+}
+
+func TestSet_NotAMapping(t *testing.T) {
+	doc, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = doc.Set("api_url", "https://api.versioner.io")
+	if err := doc.Set("api_url.host", "x"); err == nil || !strings.Contains(err.Error(), "not a mapping") {
+		t.Errorf("Expected an error setting below a scalar, got %v", err)
+	}
+	if err := doc.Set("profiles..api_url", "x"); err == nil {
+		t.Error("Expected an error for an empty key segment")
+	}
+}
+
+func TestSave_CreatesDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".versioner", "config.yaml")
+	doc, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("api_url", "https://api.versioner.io"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "api_url: https://api.versioner.io\n" {
+		t.Errorf("Unexpected content %q", data)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("- not\n- settings\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error for a config file that isn't a mapping")
+	}
+}
@@ -0,0 +1,157 @@
+package versionsource
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// readFile reads a manifest in the project directory and parses its version
+func (r *Resolver) readFile(name string, parse func([]byte) (string, error)) (string, string, error) {
+	path := filepath.Join(r.Dir, name)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", "", errNotFound
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	version, err := parse(data)
+	if err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(version), path, nil
+}
+
+// parsePackageJSON reads the version of an npm package
+func parsePackageJSON(data []byte) (string, error) {
+	var pkg struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return "", err
+	}
+	if pkg.Version == "" {
+		return "", errors.New("no version field")
+	}
+	return pkg.Version, nil
+}
+
+// parsePyproject reads the version from [project] or [tool.poetry]
+func parsePyproject(data []byte) (string, error) {
+	if version := tomlString(data, "project", "version"); version != "" {
+		return version, nil
+	}
+	if version := tomlString(data, "tool.poetry", "version"); version != "" {
+		return version, nil
+	}
+	return "", errors.New("no static version in [project] or [tool.poetry]")
+}
+
+// parseCargo reads the version of a Rust crate, or of its workspace
+func parseCargo(data []byte) (string, error) {
+	if version := tomlString(data, "package", "version"); version != "" {
+		return version, nil
+	}
+	if version := tomlString(data, "workspace.package", "version"); version != "" {
+		return version, nil
+	}
+	return "", errors.New("no version in [package] or [workspace.package]")
+}
+
+// tomlString returns a string key of a TOML table. This covers the simple
+// `key = "value"` lines manifests use for versions, not TOML in general.
+func tomlString(data []byte, table, key string) string {
+	var current string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			current = strings.TrimSpace(strings.Trim(line, "[]"))
+			continue
+		}
+		if current != table {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(name) != key {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+			if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+				return value[1 : end+1]
+			}
+		}
+	}
+	return ""
+}
+
+// parsePom reads the version of a Maven project, inherited from its parent if not set.
+// ${property} placeholders are resolved from the pom's <properties>.
+func parsePom(data []byte) (string, error) {
+	var pom struct {
+		Version string `xml:"version"`
+		Parent  struct {
+			Version string `xml:"version"`
+		} `xml:"parent"`
+		Properties struct {
+			Entries []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"properties"`
+	}
+	if err := xml.Unmarshal(data, &pom); err != nil {
+		return "", err
+	}
+
+	version := strings.TrimSpace(pom.Version)
+	if version == "" {
+		version = strings.TrimSpace(pom.Parent.Version)
+	}
+	if version == "" {
+		return "", errors.New("no project or parent version")
+	}
+
+	if name, ok := strings.CutPrefix(version, "${"); ok && strings.HasSuffix(name, "}") {
+		name = strings.TrimSuffix(name, "}")
+		for _, p := range pom.Properties.Entries {
+			if p.XMLName.Local == name {
+				return strings.TrimSpace(p.Value), nil
+			}
+		}
+		return "", errors.New("property " + name + " is not defined in the pom")
+	}
+	return version, nil
+}
+
+// parseChart reads the version of a Helm chart
+func parseChart(data []byte) (string, error) {
+	var chart struct {
+		Version string `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(data, &chart); err != nil {
+		return "", err
+	}
+	if chart.Version == "" {
+		return "", errors.New("no version field")
+	}
+	return chart.Version, nil
+}
+
+// parseVersionFile reads the first non-empty line of a VERSION file
+func parseVersionFile(data []byte) (string, error) {
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line, nil
+		}
+	}
+	return "", errors.New("file is empty")
+}
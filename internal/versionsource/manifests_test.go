@@ -0,0 +1,41 @@
+package versionsource
+
+import (
+	"testing"
+)
+
+func TestParseManifests(t *testing.T) {
+	tests := []struct {
+		name    string
+		parse   func([]byte) (string, error)
+		content string
+		want    string
+		wantErr bool
+	}{
+		{"package.json", parsePackageJSON, `{"name": "api", "version": "1.2.3"}`, "1.2.3", false},
+		{"package.json without version", parsePackageJSON, `{"name": "api"}`, "", true},
+		{"pyproject project", parsePyproject, "[build-system]\nrequires = [\"hatchling\"]\n\n[project]\nname = \"api\"\nversion = \"0.4.1\"  # bumped by release\n", "0.4.1", false},
+		{"pyproject poetry", parsePyproject, "[tool.poetry]\nname = 'api'\nversion = '3.1.0'\n", "3.1.0", false},
+		{"pyproject dynamic", parsePyproject, "[project]\nname = \"api\"\ndynamic = [\"version\"]\n", "", true},
+		{"Cargo.toml", parseCargo, "[package]\nname = \"api\"\nversion = \"0.9.0\"\n\n[dependencies]\nserde = { version = \"1\" }\n", "0.9.0", false},
+		{"Cargo.toml workspace", parseCargo, "[workspace]\nmembers = [\"a\"]\n\n[workspace.package]\nversion = \"2.2.0\"\n", "2.2.0", false},
+		{"pom.xml", parsePom, `<project><parent><version>1.0.0</version></parent><artifactId>api</artifactId><version>1.5.0-SNAPSHOT</version><dependencies><dependency><version>9.9</version></dependency></dependencies></project>`, "1.5.0-SNAPSHOT", false},
+		{"pom.xml parent version", parsePom, `<project><parent><version>1.0.0</version></parent><artifactId>api</artifactId></project>`, "1.0.0", false},
+		{"pom.xml property", parsePom, `<project><version>${revision}</version><properties><revision>4.2.0</revision></properties></project>`, "4.2.0", false},
+		{"Chart.yaml", parseChart, "apiVersion: v2\nname: api\nversion: 0.3.0\nappVersion: \"1.2.3\"\n", "0.3.0", false},
+		{"VERSION", parseVersionFile, "\n  1.2.3\n", "1.2.3", false},
+		{"empty VERSION", parseVersionFile, "\n\n", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package versionsource
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Source names, as accepted by --version-from
+const (
+	GitTag      = "git-tag"
+	GitDescribe = "git-describe"
+	PackageJSON = "package.json"
+	Pyproject   = "pyproject.toml"
+	Cargo       = "Cargo.toml"
+	Pom         = "pom.xml"
+	Chart       = "Chart.yaml"
+	VersionFile = "VERSION"
+)
+
+// DefaultSources are tried in order when no sources are configured
+var DefaultSources = []string{GitTag, GitDescribe, PackageJSON, Pyproject, Cargo, Pom, Chart, VersionFile}
+
+// errNotFound is returned by a source that has nothing to offer, such as a missing file
+var errNotFound = errors.New("not found")
+
+// Result is the version found by a source
+type Result struct {
+	Version string
+	Source  string
+	Detail  string // where the version came from, e.g. the tag or file
+}
+
+// Attempt records why a source didn't provide a version
+type Attempt struct {
+	Source string
+	Reason string
+}
+
+// Resolver finds the version of the project in a directory
+type Resolver struct {
+	// Dir is the project directory
+	Dir string
+	// Git runs a git command in dir and returns its trimmed output; tests replace it
+	Git func(dir string, args ...string) (string, error)
+}
+
+// NewResolver returns a resolver for the project in dir
+func NewResolver(dir string) *Resolver {
+	return &Resolver{Dir: dir, Git: runGit}
+}
+
+// Resolve tries sources in order and returns the first version found, or nil if none
+// had one. The attempts explain why earlier sources were passed over.
+func (r *Resolver) Resolve(sources []string) (*Result, []Attempt, error) {
+	if len(sources) == 0 {
+		sources = DefaultSources
+	}
+	for _, source := range sources {
+		if !IsValid(source) {
+			return nil, nil, fmt.Errorf("unknown version source %q (valid: %s)", source, strings.Join(DefaultSources, ", "))
+		}
+	}
+
+	var attempts []Attempt
+	for _, source := range sources {
+		version, detail, err := r.lookup(source)
+		if err == nil && version == "" {
+			err = errNotFound
+		}
+		if err != nil {
+			attempts = append(attempts, Attempt{Source: source, Reason: err.Error()})
+			continue
+		}
+		return &Result{Version: version, Source: source, Detail: detail}, attempts, nil
+	}
+	return nil, attempts, nil
+}
+
+// IsValid reports whether source is a known source name
+func IsValid(source string) bool {
+	for _, s := range DefaultSources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSources splits comma-separated source lists, as given to --version-from or in
+// config, into source names
+func ParseSources(values []string) []string {
+	var sources []string
+	for _, value := range values {
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				sources = append(sources, s)
+			}
+		}
+	}
+	return sources
+}
+
+func (r *Resolver) lookup(source string) (string, string, error) {
+	switch source {
+	case GitTag:
+		return r.gitTag()
+	case GitDescribe:
+		return r.gitDescribe()
+	case PackageJSON:
+		return r.readFile(source, parsePackageJSON)
+	case Pyproject:
+		return r.readFile(source, parsePyproject)
+	case Cargo:
+		return r.readFile(source, parseCargo)
+	case Pom:
+		return r.readFile(source, parsePom)
+	case Chart:
+		return r.readFile(source, parseChart)
+	case VersionFile:
+		return r.readFile(source, parseVersionFile)
+	}
+	return "", "", errNotFound
+}
+
+// gitTag returns the tag pointing at HEAD
+func (r *Resolver) gitTag() (string, string, error) {
+	tag, err := r.Git(r.Dir, "describe", "--tags", "--exact-match", "HEAD")
+	if err != nil {
+		return "", "", errors.New("no tag on HEAD")
+	}
+	return trimTagPrefix(tag), "tag " + tag, nil
+}
+
+// gitDescribe returns the nearest tag, followed by the number of commits since it and
+// the abbreviated commit if HEAD is not tagged (1.2.3-4-gabc1234)
+func (r *Resolver) gitDescribe() (string, string, error) {
+	described, err := r.Git(r.Dir, "describe", "--tags")
+	if err != nil {
+		return "", "", errors.New("no tags reachable from HEAD")
+	}
+	return trimTagPrefix(described), "git describe " + described, nil
+}
+
+// trimTagPrefix drops the "v" of tags like v1.2.3
+func trimTagPrefix(tag string) string {
+	if len(tag) > 1 && (tag[0] == 'v' || tag[0] == 'V') && tag[1] >= '0' && tag[1] <= '9' {
+		return tag[1:]
+	}
+	return tag
+}
+
+// runGit runs git in dir
+func runGit(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
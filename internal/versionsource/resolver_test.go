@@ -0,0 +1,118 @@
+package versionsource
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeGit answers git describe invocations from a map of joined args to output
+func fakeGit(outputs map[string]string) func(string, ...string) (string, error) {
+	return func(dir string, args ...string) (string, error) {
+		if out, ok := outputs[strings.Join(args, " ")]; ok {
+			return out, nil
+		}
+		return "", errors.New("exit status 128")
+	}
+}
+
+func TestResolve_Order(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "api", "version": "2.0.0"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		git     map[string]string
+		sources []string
+		version string
+		source  string
+	}{
+		{
+			name:    "exact tag wins",
+			git:     map[string]string{"describe --tags --exact-match HEAD": "v1.4.0", "describe --tags": "v1.4.0"},
+			version: "1.4.0",
+			source:  GitTag,
+		},
+		{
+			name:    "nearest tag plus distance",
+			git:     map[string]string{"describe --tags": "v1.4.0-3-gabc1234"},
+			version: "1.4.0-3-gabc1234",
+			source:  GitDescribe,
+		},
+		{
+			name:    "manifest without tags",
+			version: "2.0.0",
+			source:  PackageJSON,
+		},
+		{
+			name:    "selected sources",
+			git:     map[string]string{"describe --tags --exact-match HEAD": "v1.4.0"},
+			sources: []string{PackageJSON, GitTag},
+			version: "2.0.0",
+			source:  PackageJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resolver{Dir: dir, Git: fakeGit(tt.git)}
+			result, _, err := r.Resolve(tt.sources)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result == nil || result.Version != tt.version || result.Source != tt.source {
+				t.Errorf("Expected %s from %s, got %+v", tt.version, tt.source, result)
+			}
+		})
+	}
+}
+
+func TestResolve_Attempts(t *testing.T) {
+	r := &Resolver{Dir: t.TempDir(), Git: fakeGit(nil)}
+
+	result, attempts, err := r.Resolve([]string{GitTag, VersionFile})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != nil {
+		t.Errorf("Expected no version, got %+v", result)
+	}
+	want := []Attempt{{GitTag, "no tag on HEAD"}, {VersionFile, "not found"}}
+	if len(attempts) != len(want) || attempts[0] != want[0] || attempts[1] != want[1] {
+		t.Errorf("Expected %v, got %v", want, attempts)
+	}
+}
+
+func TestResolve_UnknownSource(t *testing.T) {
+	r := &Resolver{Dir: t.TempDir(), Git: fakeGit(nil)}
+	if _, _, err := r.Resolve([]string{"setup.py"}); err == nil {
+		t.Error("Expected an error for an unknown source")
+	}
+}
+
+func TestParseSources(t *testing.T) {
+	got := ParseSources([]string{"git-tag, package.json", "", "VERSION"})
+	want := []string{GitTag, PackageJSON, VersionFile}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestTrimTagPrefix(t *testing.T) {
+	tests := map[string]string{
+		"v1.2.3":      "1.2.3",
+		"1.2.3":       "1.2.3",
+		"V2.0.0-rc.1": "2.0.0-rc.1",
+		"version-5":   "version-5",
+		"v":           "v",
+	}
+	for input, want := range tests {
+		if got := trimTagPrefix(input); got != want {
+			t.Errorf("trimTagPrefix(%q) = %q, want %q", input, got, want)
+		}
+	}
+}